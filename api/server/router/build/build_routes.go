@@ -55,6 +55,7 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.Tags = r.Form["t"]
 	options.SecurityOpt = r.Form["securityopt"]
 	options.Squash = httputils.BoolValue(r, "squash")
+	options.Target = r.FormValue("target")
 
 	if r.Form.Get("shmsize") != "" {
 		shmSize, err := strconv.ParseInt(r.Form.Get("shmsize"), 10, 64)
@@ -124,6 +125,14 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 		options.CacheFrom = cacheFrom
 	}
 
+	if secretsJSON := r.FormValue("secrets"); secretsJSON != "" {
+		var secrets = map[string]string{}
+		if err := json.Unmarshal([]byte(secretsJSON), &secrets); err != nil {
+			return nil, err
+		}
+		options.Secrets = secrets
+	}
+
 	return options, nil
 }
 
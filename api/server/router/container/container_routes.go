@@ -7,9 +7,9 @@ import (
 	"net/http"
 	"strconv"
 	"syscall"
-	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
@@ -277,13 +277,59 @@ func (s *containerRouter) postContainersUnpause(ctx context.Context, w http.Resp
 }
 
 func (s *containerRouter) postContainersWait(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	status, err := s.backend.ContainerWait(vars["name"], -1*time.Second)
+	// Behavior changed in version 1.26 to handle the wait condition and to
+	// return the headers before the wait ends.
+	legacyBehavior := versions.LessThan(httputils.VersionFromContext(ctx), "1.26")
+
+	waitCondition := container.WaitConditionNotRunning
+	if !legacyBehavior {
+		if err := httputils.ParseForm(r); err != nil {
+			return err
+		}
+		switch container.WaitCondition(r.Form.Get("condition")) {
+		case "", container.WaitConditionNotRunning:
+		case container.WaitConditionNextExit:
+			waitCondition = container.WaitConditionNextExit
+		case container.WaitConditionRemoved:
+			waitCondition = container.WaitConditionRemoved
+		default:
+			return errors.NewBadRequestError(fmt.Errorf("invalid condition: %q", r.Form.Get("condition")))
+		}
+	}
+
+	// The wait is registered before the headers are sent, so that clients
+	// can wait on a container before starting it.
+	waitC, err := s.backend.ContainerWait(ctx, vars["name"], waitCondition)
 	if err != nil {
 		return err
 	}
 
-	return httputils.WriteJSON(w, http.StatusOK, &container.ContainerWaitOKBody{
-		StatusCode: int64(status),
+	w.Header().Set("Content-Type", "application/json")
+
+	if !legacyBehavior {
+		w.WriteHeader(http.StatusOK)
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	// Block on the result of the wait operation.
+	status := <-waitC
+
+	if legacyBehavior {
+		return httputils.WriteJSON(w, http.StatusOK, &container.ContainerWaitOKBody{
+			StatusCode: int64(status.ExitCode()),
+		})
+	}
+
+	var waitError *container.ContainerWaitOKBodyError
+	if err := status.Err(); err != nil {
+		waitError = &container.ContainerWaitOKBodyError{Message: err.Error()}
+	}
+
+	return json.NewEncoder(w).Encode(&container.ContainerWaitOKBody{
+		StatusCode: int64(status.ExitCode()),
+		Error:      waitError,
 	})
 }
 
@@ -100,18 +100,57 @@ func (pr *pluginRouter) getPrivileges(ctx context.Context, w http.ResponseWriter
 	return httputils.WriteJSON(w, http.StatusOK, privileges)
 }
 
-func (pr *pluginRouter) pullPlugin(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+func (pr *pluginRouter) upgradePlugin(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return errors.Wrap(err, "failed to parse form")
 	}
 
+	privileges, err := parsePrivileges(r)
+	if err != nil {
+		return err
+	}
+
+	metaHeaders, authConfig := parseHeaders(r.Header)
+
+	ref, _, err := parseRemoteRef(r.FormValue("remote"))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	output := ioutils.NewWriteFlusher(w)
+
+	force := httputils.BoolValue(r, "force")
+	if err := pr.backend.Upgrade(ctx, ref, vars["name"], metaHeaders, authConfig, privileges, force, output); err != nil {
+		if !output.Flushed() {
+			return err
+		}
+		output.Write(streamformatter.NewJSONStreamFormatter().FormatError(err))
+	}
+
+	return nil
+}
+
+func parsePrivileges(r *http.Request) (types.PluginPrivileges, error) {
 	var privileges types.PluginPrivileges
 	dec := json.NewDecoder(r.Body)
 	if err := dec.Decode(&privileges); err != nil {
-		return errors.Wrap(err, "failed to parse privileges")
+		return nil, errors.Wrap(err, "failed to parse privileges")
 	}
 	if dec.More() {
-		return errors.New("invalid privileges")
+		return nil, errors.New("invalid privileges")
+	}
+	return privileges, nil
+}
+
+func (pr *pluginRouter) pullPlugin(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return errors.Wrap(err, "failed to parse form")
+	}
+
+	privileges, err := parsePrivileges(r)
+	if err != nil {
+		return err
 	}
 
 	metaHeaders, authConfig := parseHeaders(r.Header)
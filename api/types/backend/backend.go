@@ -3,6 +3,7 @@ package backend
 
 import (
 	"io"
+	"os"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/streamformatter"
@@ -82,3 +83,16 @@ type ProgressWriter struct {
 	StderrFormatter    *streamformatter.StderrFormatter
 	ProgressReaderFunc func(io.ReadCloser) io.ReadCloser
 }
+
+// BuildSecret is a secret that is exposed to the container of a single
+// build step. It is never committed to the resulting image.
+type BuildSecret struct {
+	// ID is the identifier the secret was passed to the build with
+	ID string
+	// Target is the name of the file the secret is written to
+	Target string
+	UID    string
+	GID    string
+	Mode   os.FileMode
+	Data   []byte
+}
@@ -175,6 +175,15 @@ type ImageBuildOptions struct {
 	// specified here do not need to have a valid parent chain to match cache.
 	CacheFrom   []string
 	SecurityOpt []string
+	// Target is the name of the build stage to stop at. When empty, all
+	// stages of the Dockerfile are built.
+	Target string
+	// Secrets maps secret IDs to the path of the file holding their
+	// contents in the build context. These files are removed from the
+	// context before the build starts. Secrets are only exposed to RUN
+	// instructions that request them with `--mount=type=secret` and are
+	// never committed to the image.
+	Secrets map[string]string
 }
 
 // ImageBuildResponse holds information
@@ -318,6 +327,12 @@ type ServiceUpdateOptions struct {
 	// credentials if they are not given in EncodedRegistryAuth. Valid
 	// values are "spec" and "previous-spec".
 	RegistryAuthFrom string
+
+	// Rollback indicates whether the daemon should roll the service back
+	// to its previous specification, in which case the given spec is
+	// ignored. Valid values are "previous" and "none". An empty value is
+	// the same as "none".
+	Rollback string
 }
 
 // ServiceListOptions holds parameters to list  services with.
@@ -354,6 +369,7 @@ type PluginInstallOptions struct {
 	PrivilegeFunc         RequestPrivilegeFunc
 	AcceptPermissionsFunc func(PluginPrivileges) (bool, error)
 	Args                  []string
+	Force                 bool // Force upgrades a plugin that is in use, it is only used by PluginUpgrade
 }
 
 // SecretRequestOption is a type for requesting secrets
@@ -2,6 +2,7 @@ package container
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -191,6 +192,23 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              string
 	MaximumRetryCount int
+
+	// InitialDelay is the delay before the first restart of the container.
+	// The delay doubles at each restart. Zero means the default of 100ms.
+	InitialDelay time.Duration `json:",omitempty"`
+	// MaxDelay caps the delay between two restarts. Zero means the default
+	// of one minute.
+	MaxDelay time.Duration `json:",omitempty"`
+	// ResetWindow is how long the container has to run for the delay to be
+	// reset to InitialDelay. Zero means the default of 10 seconds.
+	ResetWindow time.Duration `json:",omitempty"`
+	// CrashLoopThreshold is the number of restarts within CrashLoopWindow
+	// above which the container is considered to be in a crash loop. Zero
+	// disables crash loop detection.
+	CrashLoopThreshold int `json:",omitempty"`
+	// CrashLoopWindow is the window in which restarts are counted towards
+	// CrashLoopThreshold. Zero means the default of 5 minutes.
+	CrashLoopWindow time.Duration `json:",omitempty"`
 }
 
 // IsNone indicates whether the container has the "no" restart policy.
@@ -220,9 +238,20 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	return *rp == *tp
 }
 
+// LogMode is a type to define the available modes for logging. These modes
+// affect how log messages are handled when the logging driver cannot keep up.
+type LogMode string
+
+// Available logging modes
+const (
+	LogModeUnset    LogMode = ""
+	LogModeBlocking LogMode = "blocking"
+	LogModeNonBlock LogMode = "non-blocking"
+)
+
 // LogConfig represents the logging configuration of the container.
 type LogConfig struct {
 	Type   string
@@ -251,6 +280,7 @@ type Resources struct {
 	CpusetCpus           string          // CpusetCpus 0-2, 0,1
 	CpusetMems           string          // CpusetMems 0-2, 0,1
 	Devices              []DeviceMapping // List of devices to map inside the container
+	DeviceCgroupRules    []string        // List of rule to be added to the device cgroup
 	DiskQuota            int64           // Disk limit (in bytes)
 	KernelMemory         int64           // Kernel memory limit (in bytes)
 	MemoryReservation    int64           // Memory soft limit (in bytes)
@@ -0,0 +1,3 @@
+// Package logdriver provides the wire types used to communicate with
+// logging driver plugins.
+package logdriver
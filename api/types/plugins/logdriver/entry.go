@@ -0,0 +1,23 @@
+package logdriver
+
+import (
+	"github.com/gogo/protobuf/proto"
+)
+
+// LogEntry is a single log message sent to (and read back from) a logging
+// driver plugin. Its wire format is described in entry.proto.
+type LogEntry struct {
+	Source   string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	TimeNano int64  `protobuf:"varint,2,opt,name=time_nano,json=timeNano,proto3" json:"time_nano,omitempty"`
+	Line     []byte `protobuf:"bytes,3,opt,name=line,proto3" json:"line,omitempty"`
+	Partial  bool   `protobuf:"varint,4,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+// Reset resets the entry to its zero value.
+func (m *LogEntry) Reset() { *m = LogEntry{} }
+
+// String returns a text representation of the entry.
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks LogEntry as a protobuf message.
+func (*LogEntry) ProtoMessage() {}
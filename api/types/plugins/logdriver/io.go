@@ -0,0 +1,95 @@
+package logdriver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	binaryEncodeLen = 4
+	// maxMsgLen is the largest encoded log entry accepted by the decoder.
+	maxMsgLen = 1e6 // 1MB
+)
+
+// LogEntryEncoder encodes a LogEntry to a protobuf stream
+// The stream should look like:
+//
+// [uint32 binary encoded message size][protobuf message]
+//
+// To decode an entry, read the first 4 bytes to get the size of the entry,
+// then read `size` bytes from the stream.
+type LogEntryEncoder interface {
+	Encode(*LogEntry) error
+}
+
+// NewLogEntryEncoder creates a protobuf stream encoder for log entries.
+// This is used to write out  log entries to a stream.
+func NewLogEntryEncoder(w io.Writer) LogEntryEncoder {
+	return &logEntryEncoder{w: w}
+}
+
+type logEntryEncoder struct {
+	w io.Writer
+}
+
+func (e *logEntryEncoder) Encode(l *LogEntry) error {
+	msg, err := proto.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if len(msg) > maxMsgLen {
+		return fmt.Errorf("log entry too large: %d bytes", len(msg))
+	}
+
+	// the size prefix and the message are written in a single call so that
+	// writes to a pipe stay atomic
+	buf := make([]byte, binaryEncodeLen+len(msg))
+	binary.BigEndian.PutUint32(buf, uint32(len(msg)))
+	copy(buf[binaryEncodeLen:], msg)
+	_, err = e.w.Write(buf)
+	return err
+}
+
+// LogEntryDecoder decodes log entries from a stream
+// It is expected that the wire format is as defined by LogEntryEncoder.
+type LogEntryDecoder interface {
+	Decode(*LogEntry) error
+}
+
+// NewLogEntryDecoder creates a new stream decoder for log entries
+func NewLogEntryDecoder(r io.Reader) LogEntryDecoder {
+	return &logEntryDecoder{
+		lenBuf: make([]byte, binaryEncodeLen),
+		r:      r,
+	}
+}
+
+type logEntryDecoder struct {
+	r      io.Reader
+	lenBuf []byte
+	buf    []byte
+}
+
+func (d *logEntryDecoder) Decode(l *LogEntry) error {
+	_, err := io.ReadFull(d.r, d.lenBuf)
+	if err != nil {
+		return err
+	}
+
+	size := int(binary.BigEndian.Uint32(d.lenBuf))
+	if size > maxMsgLen {
+		return fmt.Errorf("log entry is too large to decode: %d bytes", size)
+	}
+	if len(d.buf) < size {
+		d.buf = make([]byte, size)
+	}
+
+	if _, err := io.ReadFull(d.r, d.buf[:size]); err != nil {
+		return err
+	}
+	l.Reset()
+	return proto.Unmarshal(d.buf[:size], l)
+}
@@ -0,0 +1,47 @@
+package logdriver
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestLogEntryEncodeDecode(t *testing.T) {
+	entries := []*LogEntry{
+		{Source: "stdout", TimeNano: 1234, Line: []byte("hello")},
+		{Source: "stderr", TimeNano: 5678, Line: []byte("partial"), Partial: true},
+		{Source: "stdout"},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := NewLogEntryEncoder(buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dec := NewLogEntryDecoder(buf)
+	for i, expected := range entries {
+		var e LogEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("entry %d: %v", i, err)
+		}
+		if e.Source != expected.Source || e.TimeNano != expected.TimeNano || string(e.Line) != string(expected.Line) || e.Partial != expected.Partial {
+			t.Fatalf("entry %d: expected %v, got %v", i, expected, &e)
+		}
+	}
+
+	var e LogEntry
+	if err := dec.Decode(&e); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestLogEntryDecodeTooLarge(t *testing.T) {
+	dec := NewLogEntryDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	var e LogEntry
+	if err := dec.Decode(&e); err == nil {
+		t.Fatal("expected error decoding oversized entry")
+	}
+}
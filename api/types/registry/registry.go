@@ -10,6 +10,18 @@ type ServiceConfig struct {
 	InsecureRegistryCIDRs []*NetIPNet           `json:"InsecureRegistryCIDRs"`
 	IndexConfigs          map[string]*IndexInfo `json:"IndexConfigs"`
 	Mirrors               []string
+	// HostMirrors maps the hostname of a registry to its mirrors, in the
+	// order they are tried when pulling images.
+	HostMirrors map[string][]MirrorInfo `json:",omitempty"`
+}
+
+// MirrorInfo contains information about a mirror of a registry
+type MirrorInfo struct {
+	// URL is the endpoint of the mirror, such as "https://mirror.example.com/"
+	URL string
+	// Insecure is set to true if the TLS certificate of the mirror is not
+	// verified.
+	Insecure bool
 }
 
 // NetIPNet is the net.IPNet type, which can be marshalled and
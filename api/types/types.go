@@ -313,18 +313,19 @@ type Health struct {
 // ContainerState stores container's running state
 // it's part of ContainerJSONBase and will return by "inspect" command
 type ContainerState struct {
-	Status     string
-	Running    bool
-	Paused     bool
-	Restarting bool
-	OOMKilled  bool
-	Dead       bool
-	Pid        int
-	ExitCode   int
-	Error      string
-	StartedAt  string
-	FinishedAt string
-	Health     *Health `json:",omitempty"`
+	Status       string
+	Running      bool
+	Paused       bool
+	Restarting   bool
+	CrashLooping bool
+	OOMKilled    bool
+	Dead         bool
+	Pid          int
+	ExitCode     int
+	Error        string
+	StartedAt    string
+	FinishedAt   string
+	Health       *Health `json:",omitempty"`
 }
 
 // ContainerNode stores information about the node that a container
@@ -48,6 +48,9 @@ type Volume struct {
 // swagger:model VolumeUsageData
 type VolumeUsageData struct {
 
+	// The size limit of the volume, or 0 if the volume is not limited (local driver only)
+	Limit int64 `json:"Limit,omitempty"`
+
 	// The number of containers referencing this volume.
 	// Required: true
 	RefCount int64 `json:"RefCount"`
@@ -7,11 +7,11 @@ package builder
 import (
 	"io"
 	"os"
-	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/reference"
 	"golang.org/x/net/context"
@@ -125,10 +125,13 @@ type Backend interface {
 	ContainerKill(containerID string, sig uint64) error
 	// ContainerStart starts a new container
 	ContainerStart(containerID string, hostConfig *container.HostConfig, checkpoint string, checkpointDir string) error
-	// ContainerWait stops processing until the given container is stopped.
-	ContainerWait(containerID string, timeout time.Duration) (int, error)
+	// ContainerWait waits until the given container is in the state
+	// indicated by the condition.
+	ContainerWait(ctx context.Context, containerID string, condition container.WaitCondition) (<-chan containerpkg.StateStatus, error)
 	// ContainerUpdateCmdOnBuild updates container.Path and container.Args
 	ContainerUpdateCmdOnBuild(containerID string, cmd []string) error
+	// ContainerSetSecretsOnBuild sets the secrets to mount into a build container
+	ContainerSetSecretsOnBuild(containerID string, secrets []backend.BuildSecret) error
 	// ContainerCreateWorkdir creates the workdir
 	ContainerCreateWorkdir(containerID string) error
 
@@ -139,13 +142,18 @@ type Backend interface {
 	// with Context.Walk
 	// ContainerCopy(name string, res string) (io.ReadCloser, error)
 	// TODO: use copyBackend api
-	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool) error
+	// `chown` is a "user[:group]" string resolved against the container's
+	// /etc/passwd and /etc/group. When empty, files are owned by root.
+	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool, chown string) error
 
 	// HasExperimental checks if the backend supports experimental features
 	HasExperimental() bool
 
 	// SquashImage squashes the fs layers from the provided image down to the specified `to` image
 	SquashImage(from string, to string) (string, error)
+
+	// MountImage returns mounted path with rootfs of an image.
+	MountImage(name string) (string, func() error, error)
 }
 
 // Image represents a Docker image used by the builder.
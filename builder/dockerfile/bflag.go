@@ -11,6 +11,7 @@ type FlagType int
 const (
 	boolType FlagType = iota
 	stringType
+	stringsType
 )
 
 // BFlags contains all flags information for the builder
@@ -23,10 +24,11 @@ type BFlags struct {
 
 // Flag contains all information for a flag
 type Flag struct {
-	bf       *BFlags
-	name     string
-	flagType FlagType
-	Value    string
+	bf           *BFlags
+	name         string
+	flagType     FlagType
+	Value        string
+	StringValues []string
 }
 
 // NewBFlags returns the new BFlags struct
@@ -63,6 +65,13 @@ func (bf *BFlags) AddString(name string, def string) *Flag {
 	return flag
 }
 
+// AddStrings adds a string flag to BFlags that may be specified more than
+// once. Each value is appended to the flag's StringValues.
+// Note, any error will be generated when Parse() is called (see Parse).
+func (bf *BFlags) AddStrings(name string) *Flag {
+	return bf.addFlag(name, stringsType)
+}
+
 // addFlag is a generic func used by the other AddXXX() func
 // to add a new flag to the BFlags struct.
 // Note, any error will be generated when Parse() is called (see Parse).
@@ -138,7 +147,7 @@ func (bf *BFlags) Parse() error {
 			return fmt.Errorf("Unknown flag: %s", arg)
 		}
 
-		if _, ok = bf.used[arg]; ok {
+		if _, ok = bf.used[arg]; ok && flag.flagType != stringsType {
 			return fmt.Errorf("Duplicate flag specified: %s", arg)
 		}
 
@@ -166,6 +175,12 @@ func (bf *BFlags) Parse() error {
 			}
 			flag.Value = value
 
+		case stringsType:
+			if index < 0 {
+				return fmt.Errorf("Missing a value on flag: %s", arg)
+			}
+			flag.StringValues = append(flag.StringValues, value)
+
 		default:
 			panic(fmt.Errorf("No idea what kind of flag we have! Should never get here!"))
 		}
@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/command"
 	"github.com/docker/docker/builder/dockerfile/parser"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/stringid"
@@ -74,12 +75,14 @@ type Builder struct {
 	cacheBusted      bool
 	allowedBuildArgs map[string]bool // list of build-time args that are allowed for expansion/substitution and passing to commands in 'run'.
 	directive        parser.Directive
+	secrets          map[string][]byte // contents of the build secrets, keyed by secret ID
 
 	// TODO: remove once docker.Commit can receive a tag
 	id string
 
-	imageCache builder.ImageCache
-	from       builder.Image
+	imageCache    builder.ImageCache
+	from          builder.Image
+	imageContexts *imageContexts // helper for storing contexts from builds
 }
 
 // BuildManager implements builder.Backend and is shared across all Builder objects.
@@ -145,9 +148,8 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 			LookingForDirectives: true,
 		},
 	}
-	if icb, ok := backend.(builder.ImageCacheBuilder); ok {
-		b.imageCache = icb.MakeImageCache(config.CacheFrom)
-	}
+	b.imageContexts = &imageContexts{b: b}
+	b.resetImageCache()
 
 	parser.SetEscapeToken(parser.DefaultEscapeToken, &b.directive) // Assume the default token for escape
 
@@ -161,6 +163,16 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 	return b, nil
 }
 
+// resetImageCache starts a fresh image cache, used at the beginning of
+// every build stage.
+func (b *Builder) resetImageCache() {
+	if icb, ok := b.docker.(builder.ImageCacheBuilder); ok {
+		b.imageCache = icb.MakeImageCache(b.options.CacheFrom)
+	}
+	b.noBaseImage = false
+	b.cacheBusted = false
+}
+
 // sanitizeRepoAndTags parses the raw "t" parameter received from the client
 // to a slice of repoAndTag.
 // It also validates each repoName and tag.
@@ -221,6 +233,12 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 	b.Stderr = stderr
 	b.Output = out
 
+	defer b.imageContexts.unmount()
+
+	if err := b.readSecrets(); err != nil {
+		return "", err
+	}
+
 	// If Dockerfile was not parsed yet, extract it from the Context
 	if b.dockerfile == nil {
 		if err := b.readDockerfile(); err != nil {
@@ -263,6 +281,11 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 			// Not cancelled yet, keep going...
 		}
 
+		// Stop before the next build stage once the target stage is done
+		if n.Value == command.From && b.imageContexts.isCurrentTarget(b.options.Target) {
+			break
+		}
+
 		if err := b.dispatch(i, total, n); err != nil {
 			if b.options.ForceRemove {
 				b.clearTmp()
@@ -270,6 +293,8 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 			return "", err
 		}
 
+		b.imageContexts.update(b.image, b.runConfig)
+
 		shortImgID = stringid.TruncateID(b.image)
 		fmt.Fprintf(b.Stdout, " ---> %s\n", shortImgID)
 		if b.options.Remove {
@@ -290,6 +315,10 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		fmt.Fprintf(b.Stderr, "[Warning] One or more build-args %v were not consumed\n", leftoverArgs)
 	}
 
+	if b.options.Target != "" && !b.imageContexts.isCurrentTarget(b.options.Target) {
+		return "", perrors.Errorf("failed to reach build target %s in Dockerfile", b.options.Target)
+	}
+
 	if b.image == "" {
 		return "", fmt.Errorf("No image was generated. Is your Dockerfile empty?")
 	}
@@ -27,6 +27,10 @@ import (
 	"github.com/docker/go-connections/nat"
 )
 
+// validStageName matches the names that can be given to build stages with
+// `FROM image AS name`.
+var validStageName = regexp.MustCompile(`^[a-z][a-z0-9-_\.]*$`)
+
 // ENV foo bar
 //
 // Sets the environment variable foo to bar, also makes interpolation
@@ -165,11 +169,22 @@ func add(b *Builder, args []string, attributes map[string]bool, original string)
 		return errAtLeastTwoArguments("ADD")
 	}
 
+	flFrom := b.flags.AddString("from", "")
+	flChown := b.flags.AddString("chown", "")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
+	if flChown.IsUsed() && flChown.Value == "" {
+		return fmt.Errorf("ADD --chown requires a user and optional group, as in --chown=user:group")
+	}
+
+	im, err := b.getImageSource(flFrom)
+	if err != nil {
+		return err
+	}
 
-	return b.runContextCommand(args, true, true, "ADD")
+	return b.runContextCommand(args, true, true, "ADD", im, flChown.Value)
 }
 
 // COPY foo /path
@@ -181,20 +196,39 @@ func dispatchCopy(b *Builder, args []string, attributes map[string]bool, origina
 		return errAtLeastTwoArguments("COPY")
 	}
 
+	flFrom := b.flags.AddString("from", "")
+	flChown := b.flags.AddString("chown", "")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
+	if flChown.IsUsed() && flChown.Value == "" {
+		return fmt.Errorf("COPY --chown requires a user and optional group, as in --chown=user:group")
+	}
+
+	im, err := b.getImageSource(flFrom)
+	if err != nil {
+		return err
+	}
 
-	return b.runContextCommand(args, false, false, "COPY")
+	return b.runContextCommand(args, false, false, "COPY", im, flChown.Value)
 }
 
-// FROM imagename
+// FROM imagename[ AS name]
 //
-// This sets the image the dockerfile will build on top of.
+// This sets the image the dockerfile will build on top of. Every FROM starts
+// a new build stage, which can be given a name to refer to it from later
+// FROM instructions and from the --from flag of COPY and ADD.
 //
 func from(b *Builder, args []string, attributes map[string]bool, original string) error {
-	if len(args) != 1 {
-		return errExactlyOneArgument("FROM")
+	ctxName := ""
+	if len(args) == 3 && strings.EqualFold(args[1], "as") {
+		ctxName = strings.ToLower(args[2])
+		if !validStageName.MatchString(ctxName) {
+			return fmt.Errorf("invalid name for build stage: %q, name can't start with a number or contain symbols", args[2])
+		}
+	} else if len(args) != 1 {
+		return fmt.Errorf("FROM requires either one or three arguments")
 	}
 
 	if err := b.flags.Parse(); err != nil {
@@ -208,6 +242,8 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		err   error
 	)
 
+	b.resetImageCache()
+
 	// Windows cannot support a container with no base image.
 	if name == api.NoBaseImageSpecifier {
 		if runtime.GOOS == "windows" {
@@ -215,21 +251,32 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		}
 		b.image = ""
 		b.noBaseImage = true
-	} else {
-		// TODO: don't use `name`, instead resolve it to a digest
-		if !b.options.PullParent {
-			image, err = b.docker.GetImageOnBuild(name)
-			// TODO: shouldn't we error out if error is different from "not found" ?
+	} else if im, ok := b.imageContexts.byName[strings.ToLower(name)]; ok {
+		// FROM a previous build stage
+		if im.ImageID() == "" {
+			return fmt.Errorf("build stage %s did not produce an image", name)
 		}
-		if image == nil {
-			image, err = b.docker.PullOnBuild(b.clientCtx, name, b.options.AuthConfigs, b.Output)
-			if err != nil {
-				return err
-			}
+		image, err = b.docker.GetImageOnBuild(im.ImageID())
+		if err != nil {
+			return err
+		}
+	} else {
+		image, err = b.getImage(name)
+		if err != nil {
+			return err
 		}
 	}
 	b.from = image
 
+	if _, err := b.imageContexts.new(ctxName, true); err != nil {
+		return err
+	}
+
+	// Every build stage starts with a clean configuration
+	b.runConfig = new(container.Config)
+	b.maintainer = ""
+	b.cmdSet = false
+
 	return b.processImageFrom(image)
 }
 
@@ -334,10 +381,19 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		return fmt.Errorf("Please provide a source image with `from` prior to run")
 	}
 
+	flMount := b.flags.AddStrings("mount")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
+	// secrets are deliberately left out of the command used for the cache
+	// lookup and the commit, so that they never end up in the image.
+	secrets, err := parseRunSecrets(flMount.StringValues, b.secrets)
+	if err != nil {
+		return err
+	}
+
 	args = handleJSONArgs(args, attributes)
 
 	if !attributes["json"] {
@@ -426,6 +482,10 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		return err
 	}
 
+	if err := b.docker.ContainerSetSecretsOnBuild(cID, secrets); err != nil {
+		return err
+	}
+
 	if err := b.run(cID); err != nil {
 		return err
 	}
@@ -517,6 +577,7 @@ func healthcheck(b *Builder, args []string, attributes map[string]bool, original
 
 		flInterval := b.flags.AddString("interval", "")
 		flTimeout := b.flags.AddString("timeout", "")
+		flStartPeriod := b.flags.AddString("start-period", "")
 		flRetries := b.flags.AddString("retries", "")
 
 		if err := b.flags.Parse(); err != nil {
@@ -535,8 +596,21 @@ func healthcheck(b *Builder, args []string, attributes map[string]bool, original
 			}
 
 			healthcheck.Test = strslice.StrSlice(append([]string{typ}, cmdSlice...))
+		case "HTTP", "TCP":
+			probeArgs := args
+			if !attributes["json"] {
+				probeArgs = strings.Fields(strings.Join(args, " "))
+			}
+			if typ == "HTTP" && (len(probeArgs) < 1 || len(probeArgs) > 2) {
+				return fmt.Errorf("HEALTHCHECK HTTP requires a URL and an optional status range")
+			}
+			if typ == "TCP" && len(probeArgs) != 1 {
+				return fmt.Errorf("HEALTHCHECK TCP requires exactly one address")
+			}
+
+			healthcheck.Test = strslice.StrSlice(append([]string{typ}, probeArgs...))
 		default:
-			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD)", typ)
+			return fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD, HTTP or TCP)", typ)
 		}
 
 		interval, err := parseOptInterval(flInterval)
@@ -551,6 +625,12 @@ func healthcheck(b *Builder, args []string, attributes map[string]bool, original
 		}
 		healthcheck.Timeout = timeout
 
+		startPeriod, err := parseOptInterval(flStartPeriod)
+		if err != nil {
+			return err
+		}
+		healthcheck.StartPeriod = startPeriod
+
 		if flRetries.Value != "" {
 			retries, err := strconv.ParseInt(flRetries.Value, 10, 32)
 			if err != nil {
@@ -5,6 +5,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -20,7 +21,6 @@ type commandWithFunction struct {
 func TestCommandsExactlyOneArgument(t *testing.T) {
 	commands := []commandWithFunction{
 		{"MAINTAINER", func(args []string) error { return maintainer(nil, args, nil, "") }},
-		{"FROM", func(args []string) error { return from(nil, args, nil, "") }},
 		{"WORKDIR", func(args []string) error { return workdir(nil, args, nil, "") }},
 		{"USER", func(args []string) error { return user(nil, args, nil, "") }},
 		{"STOPSIGNAL", func(args []string) error { return stopSignal(nil, args, nil, "") }}}
@@ -130,6 +130,32 @@ func TestCommandseBlankNames(t *testing.T) {
 	}
 }
 
+func TestCopyAddEmptyChown(t *testing.T) {
+	commands := []struct {
+		name     string
+		function func(b *Builder, args []string, attributes map[string]bool, original string) error
+	}{
+		{"ADD", add},
+		{"COPY", dispatchCopy},
+	}
+
+	for _, command := range commands {
+		bflags := NewBFlags()
+		bflags.Args = []string{"--chown="}
+		b := &Builder{flags: bflags, runConfig: &container.Config{}, disableCommit: true}
+
+		err := command.function(b, []string{"src", "dest"}, nil, "")
+		if err == nil {
+			t.Fatalf("Error should be present for %s command", command.name)
+		}
+
+		expectedError := command.name + " --chown requires a user and optional group, as in --chown=user:group"
+		if err.Error() != expectedError {
+			t.Fatalf("Wrong error message for %s. Got: %s. Should be: %s", command.name, err.Error(), expectedError)
+		}
+	}
+}
+
 func TestEnv2Variables(t *testing.T) {
 	variables := []string{"var1", "val1", "var2", "val2"}
 
@@ -189,8 +215,70 @@ func TestLabel(t *testing.T) {
 	}
 }
 
+func TestFromWrongNumberOfArguments(t *testing.T) {
+	for _, args := range [][]string{{}, {"busybox", "AS"}, {"busybox", "foo", "bar"}, {"busybox", "AS", "foo", "bar"}} {
+		err := from(nil, args, nil, "")
+
+		if err == nil {
+			t.Fatalf("Error should be present for FROM %v", args)
+		}
+
+		expectedError := "FROM requires either one or three arguments"
+
+		if err.Error() != expectedError {
+			t.Fatalf("Wrong error message for FROM %v. Got: %s. Should be: %s", args, err.Error(), expectedError)
+		}
+	}
+}
+
+func TestFromInvalidStageName(t *testing.T) {
+	for _, name := range []string{"1stage", "stage!", "-stage"} {
+		err := from(nil, []string{"busybox", "AS", name}, nil, "")
+
+		if err == nil {
+			t.Fatalf("Error should be present for stage name %q", name)
+		}
+
+		if !strings.Contains(err.Error(), "invalid name for build stage") {
+			t.Fatalf("Error message not correct for stage name %q, got: %s", name, err.Error())
+		}
+	}
+}
+
+func TestFromNamedStage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not support FROM scratch")
+	}
+
+	b := &Builder{flags: &BFlags{}, runConfig: &container.Config{}, disableCommit: true}
+	b.imageContexts = &imageContexts{b: b}
+
+	if err := from(b, []string{"scratch", "AS", "Base"}, nil, ""); err != nil {
+		t.Fatalf("Error when executing from: %s", err.Error())
+	}
+
+	if _, ok := b.imageContexts.byName["base"]; !ok {
+		t.Fatalf("Build stage should be registered with a lowercase name")
+	}
+
+	if !b.imageContexts.isCurrentTarget("base") {
+		t.Fatalf("Current build stage should be the target")
+	}
+
+	err := from(b, []string{"scratch", "as", "base"}, nil, "")
+
+	if err == nil {
+		t.Fatalf("Error should be present for a duplicate build stage name")
+	}
+
+	if !strings.Contains(err.Error(), "duplicate name base") {
+		t.Fatalf("Error message not correct, got: %s", err.Error())
+	}
+}
+
 func TestFrom(t *testing.T) {
 	b := &Builder{flags: &BFlags{}, runConfig: &container.Config{}, disableCommit: true}
+	b.imageContexts = &imageContexts{b: b}
 
 	err := from(b, []string{"scratch"}, nil, "")
 
@@ -355,6 +443,65 @@ func TestHealthcheckCmd(t *testing.T) {
 	}
 }
 
+func TestHealthcheckStartPeriod(t *testing.T) {
+	bflags := NewBFlags()
+	bflags.Args = []string{"--start-period=90s"}
+	b := &Builder{flags: bflags, runConfig: &container.Config{}, disableCommit: true}
+
+	if err := healthcheck(b, []string{"CMD", "/check.sh"}, nil, ""); err != nil {
+		t.Fatalf("Error should be empty, got: %s", err.Error())
+	}
+
+	if b.runConfig.Healthcheck.StartPeriod != 90*time.Second {
+		t.Fatalf("StartPeriod should be set to 90s, got %s", b.runConfig.Healthcheck.StartPeriod)
+	}
+
+	bflags = NewBFlags()
+	bflags.Args = []string{"--start-period=0s"}
+	b = &Builder{flags: bflags, runConfig: &container.Config{}, disableCommit: true}
+
+	if err := healthcheck(b, []string{"CMD", "/check.sh"}, nil, ""); err == nil {
+		t.Fatal("Error should be present for a zero start period")
+	}
+}
+
+func TestHealthcheckHTTPAndTCP(t *testing.T) {
+	testCases := []struct {
+		args       []string
+		attributes map[string]bool
+		expected   strslice.StrSlice
+	}{
+		{[]string{"HTTP", "http://localhost:8080/health 200-299"}, nil, strslice.StrSlice{"HTTP", "http://localhost:8080/health", "200-299"}},
+		{[]string{"HTTP", "http://localhost/"}, map[string]bool{"json": true}, strslice.StrSlice{"HTTP", "http://localhost/"}},
+		{[]string{"TCP", "localhost:5432"}, nil, strslice.StrSlice{"TCP", "localhost:5432"}},
+	}
+
+	for _, testCase := range testCases {
+		b := &Builder{flags: &BFlags{flags: make(map[string]*Flag)}, runConfig: &container.Config{}, disableCommit: true}
+
+		if err := healthcheck(b, testCase.args, testCase.attributes, ""); err != nil {
+			t.Fatalf("Error should be empty, got: %s", err.Error())
+		}
+
+		if !compareStrSlice(testCase.expected, b.runConfig.Healthcheck.Test) {
+			t.Fatalf("Command should be set to %s, got %s", testCase.expected, b.runConfig.Healthcheck.Test)
+		}
+	}
+
+	invalid := [][]string{
+		{"HTTP", ""},
+		{"HTTP", "http://localhost/ 200 extra"},
+		{"TCP", "localhost:1 localhost:2"},
+	}
+	for _, args := range invalid {
+		b := &Builder{flags: &BFlags{flags: make(map[string]*Flag)}, runConfig: &container.Config{}, disableCommit: true}
+
+		if err := healthcheck(b, args, nil, ""); err == nil {
+			t.Fatalf("Error should be present for %v", args)
+		}
+	}
+}
+
 func TestEntrypoint(t *testing.T) {
 	b := &Builder{flags: &BFlags{}, runConfig: &container.Config{}, disableCommit: true}
 
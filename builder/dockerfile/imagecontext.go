@@ -0,0 +1,186 @@
+package dockerfile
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
+	"github.com/pkg/errors"
+)
+
+// imageContexts is a helper for stacking up built image rootfs and reusing
+// them as contexts
+type imageContexts struct {
+	b      *Builder
+	list   []*imageMount
+	byName map[string]*imageMount
+	byRef  map[string]*imageMount
+}
+
+// new adds a new image context. If name is not empty the context can later
+// be referenced by it. Only contexts created with increment set are build
+// stages that can be referenced by index.
+func (ic *imageContexts) new(name string, increment bool) (*imageMount, error) {
+	im := &imageMount{ic: ic}
+	if len(name) > 0 {
+		if ic.byName == nil {
+			ic.byName = make(map[string]*imageMount)
+		}
+		if _, ok := ic.byName[name]; ok {
+			return nil, errors.Errorf("duplicate name %s", name)
+		}
+		ic.byName[name] = im
+	}
+	if increment {
+		ic.list = append(ic.list, im)
+	}
+	return im, nil
+}
+
+// update records the image and config produced so far by the current build
+// stage.
+func (ic *imageContexts) update(imageID string, runConfig *container.Config) {
+	if len(ic.list) == 0 {
+		return
+	}
+	ic.list[len(ic.list)-1].id = imageID
+	ic.list[len(ic.list)-1].runConfig = runConfig
+}
+
+func (ic *imageContexts) validate(i int) error {
+	if i < 0 || i >= len(ic.list)-1 {
+		var extraMsg string
+		if i == len(ic.list)-1 {
+			extraMsg = " refers current build block"
+		}
+		return errors.Errorf("invalid from flag value %d%s", i, extraMsg)
+	}
+	return nil
+}
+
+// get returns the image context for a build stage index, a build stage
+// name or an image reference, in that order.
+func (ic *imageContexts) get(indexOrName string) (*imageMount, error) {
+	index, err := strconv.Atoi(indexOrName)
+	if err == nil {
+		if err := ic.validate(index); err != nil {
+			return nil, err
+		}
+		return ic.list[index], nil
+	}
+	if im, ok := ic.byName[strings.ToLower(indexOrName)]; ok {
+		if im == ic.list[len(ic.list)-1] {
+			return nil, errors.Errorf("invalid from flag value %s refers current build block", indexOrName)
+		}
+		return im, nil
+	}
+	if im, ok := ic.byRef[indexOrName]; ok {
+		return im, nil
+	}
+	im, err := ic.mountByRef(indexOrName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid from flag value %s", indexOrName)
+	}
+	return im, nil
+}
+
+func (ic *imageContexts) mountByRef(name string) (*imageMount, error) {
+	image, err := ic.b.getImage(name)
+	if err != nil {
+		return nil, err
+	}
+	im, err := ic.new("", false)
+	if err != nil {
+		return nil, err
+	}
+	im.id = image.ImageID()
+	im.runConfig = image.RunConfig()
+	if ic.byRef == nil {
+		ic.byRef = make(map[string]*imageMount)
+	}
+	ic.byRef[name] = im
+	return im, nil
+}
+
+// isCurrentTarget returns true if the current build stage is named target.
+func (ic *imageContexts) isCurrentTarget(target string) bool {
+	if target == "" || len(ic.list) == 0 {
+		return false
+	}
+	for name, im := range ic.byName {
+		if im == ic.list[len(ic.list)-1] {
+			return name == strings.ToLower(target)
+		}
+	}
+	return false
+}
+
+// unmount releases all images that were mounted as contexts.
+func (ic *imageContexts) unmount() (retErr error) {
+	for _, im := range ic.list {
+		if err := im.unmount(); err != nil {
+			logrus.Error(err)
+			retErr = err
+		}
+	}
+	for _, im := range ic.byRef {
+		if err := im.unmount(); err != nil {
+			logrus.Error(err)
+			retErr = err
+		}
+	}
+	return
+}
+
+// imageMount is a reference for getting access to a build context that is
+// backed by an existing image
+type imageMount struct {
+	id        string
+	ctx       builder.Context
+	release   func() error
+	ic        *imageContexts
+	runConfig *container.Config
+}
+
+// context returns the build context for the image, mounting the image rootfs
+// the first time it is called.
+func (im *imageMount) context() (builder.Context, error) {
+	if im.ctx == nil {
+		if im.id == "" {
+			return nil, errors.Errorf("could not copy from empty context")
+		}
+		p, release, err := im.ic.b.docker.MountImage(im.id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to mount %s", im.id)
+		}
+		ctx, err := builder.NewLazyContext(p)
+		if err != nil {
+			release()
+			return nil, errors.Wrapf(err, "failed to create lazycontext for %s", p)
+		}
+		im.release = release
+		im.ctx = ctx
+	}
+	return im.ctx, nil
+}
+
+func (im *imageMount) unmount() error {
+	if im.release != nil {
+		if err := im.release(); err != nil {
+			return errors.Wrapf(err, "failed to unmount previous build image %s", im.id)
+		}
+		im.release = nil
+		im.ctx = nil
+	}
+	return nil
+}
+
+func (im *imageMount) ImageID() string {
+	return im.id
+}
+
+func (im *imageMount) RunConfig() *container.Config {
+	return im.runConfig
+}
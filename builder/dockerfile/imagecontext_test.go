@@ -0,0 +1,87 @@
+package dockerfile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageContextsGetByIndex(t *testing.T) {
+	ic := &imageContexts{}
+	first, err := ic.new("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ic.new("", true); err != nil {
+		t.Fatal(err)
+	}
+
+	im, err := ic.get("0")
+	if err != nil {
+		t.Fatalf("Error when getting build stage 0: %s", err.Error())
+	}
+	if im != first {
+		t.Fatalf("Wrong build stage returned for index 0")
+	}
+
+	for index, expectedError := range map[string]string{
+		"1":  "invalid from flag value 1 refers current build block",
+		"2":  "invalid from flag value 2",
+		"-1": "invalid from flag value -1",
+	} {
+		_, err := ic.get(index)
+		if err == nil {
+			t.Fatalf("Error should be present for index %s", index)
+		}
+		if err.Error() != expectedError {
+			t.Fatalf("Wrong error message for index %s. Got: %s. Should be: %s", index, err.Error(), expectedError)
+		}
+	}
+}
+
+func TestImageContextsGetByName(t *testing.T) {
+	ic := &imageContexts{}
+	builder, err := ic.new("builder", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ic.new("final", true); err != nil {
+		t.Fatal(err)
+	}
+
+	im, err := ic.get("Builder")
+	if err != nil {
+		t.Fatalf("Error when getting build stage by name: %s", err.Error())
+	}
+	if im != builder {
+		t.Fatalf("Wrong build stage returned for name builder")
+	}
+
+	_, err = ic.get("final")
+	if err == nil || !strings.Contains(err.Error(), "refers current build block") {
+		t.Fatalf("Error should be present when referring to the current build stage, got: %v", err)
+	}
+}
+
+func TestImageContextsIsCurrentTarget(t *testing.T) {
+	ic := &imageContexts{}
+	if ic.isCurrentTarget("builder") {
+		t.Fatalf("No build stage should be the target before the first FROM")
+	}
+
+	if _, err := ic.new("builder", true); err != nil {
+		t.Fatal(err)
+	}
+	if !ic.isCurrentTarget("builder") {
+		t.Fatalf("builder should be the current target")
+	}
+	if ic.isCurrentTarget("") {
+		t.Fatalf("An empty target should never match")
+	}
+
+	if _, err := ic.new("", true); err != nil {
+		t.Fatal(err)
+	}
+	if ic.isCurrentTarget("builder") {
+		t.Fatalf("builder should not be the current target after a new stage started")
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/docker/docker/pkg/tarsum"
 	"github.com/docker/docker/pkg/urlutil"
 	"github.com/docker/docker/runconfig/opts"
+	"golang.org/x/net/context"
 )
 
 func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) error {
@@ -91,15 +92,25 @@ type copyInfo struct {
 	decompress bool
 }
 
-func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string) error {
-	if b.context == nil {
-		return fmt.Errorf("No context given. Impossible to use %s", cmdName)
-	}
-
+func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string, imageSource *imageMount, chown string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("Invalid %s format - at least two arguments required", cmdName)
 	}
 
+	// The files are copied either from the build context or from the rootfs
+	// of another image when --from was given.
+	context := b.context
+	if imageSource != nil {
+		var err error
+		context, err = imageSource.context()
+		if err != nil {
+			return err
+		}
+	}
+	if context == nil {
+		return fmt.Errorf("No context given. Impossible to use %s", cmdName)
+	}
+
 	// Work in daemon-specific filepath semantics
 	dest := filepath.FromSlash(args[len(args)-1]) // last one is always the dest
 
@@ -115,7 +126,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		var fi builder.FileInfo
 		decompress := allowLocalDecompression
 		if urlutil.IsURL(orig) {
-			if !allowRemote {
+			if !allowRemote || imageSource != nil {
 				return fmt.Errorf("Source can't be a URL for %s", cmdName)
 			}
 			fi, err = b.download(orig)
@@ -128,7 +139,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 			continue
 		}
 		// not a URL
-		subInfos, err := b.calcCopyInfo(cmdName, orig, allowLocalDecompression, true)
+		subInfos, err := b.calcCopyInfo(context, cmdName, orig, allowLocalDecompression, true)
 		if err != nil {
 			return err
 		}
@@ -171,7 +182,13 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 
 	cmd := b.runConfig.Cmd
-	b.runConfig.Cmd = strslice.StrSlice(append(getShell(b.runConfig), fmt.Sprintf("#(nop) %s %s in %s ", cmdName, srcHash, dest)))
+	// the ownership is only part of the cache key when it was requested, so
+	// that existing cache entries remain valid
+	nopCmd := cmdName
+	if chown != "" {
+		nopCmd += " --chown=" + chown
+	}
+	b.runConfig.Cmd = strslice.StrSlice(append(getShell(b.runConfig), fmt.Sprintf("#(nop) %s %s in %s ", nopCmd, srcHash, dest)))
 	defer func(cmd strslice.StrSlice) { b.runConfig.Cmd = cmd }(cmd)
 
 	if hit, err := b.probeCache(); err != nil {
@@ -186,7 +203,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 	b.tmpContainers[container.ID] = struct{}{}
 
-	comment := fmt.Sprintf("%s %s in %s", cmdName, origPaths, dest)
+	comment := fmt.Sprintf("%s %s in %s", nopCmd, origPaths, dest)
 
 	// Twiddle the destination when it's a relative path - meaning, make it
 	// relative to the WORKINGDIR
@@ -195,7 +212,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 
 	for _, info := range infos {
-		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress); err != nil {
+		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress, chown); err != nil {
 			return err
 		}
 	}
@@ -294,7 +311,7 @@ func (b *Builder) download(srcURL string) (fi builder.FileInfo, err error) {
 	return &builder.HashedFileInfo{FileInfo: builder.PathFileInfo{FileInfo: tmpFileSt, FilePath: tmpFileName}, FileHash: hash}, nil
 }
 
-func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression, allowWildcards bool) ([]copyInfo, error) {
+func (b *Builder) calcCopyInfo(context builder.Context, cmdName, origPath string, allowLocalDecompression, allowWildcards bool) ([]copyInfo, error) {
 
 	// Work in daemon-specific OS filepath semantics
 	origPath = filepath.FromSlash(origPath)
@@ -307,7 +324,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	// Deal with wildcards
 	if allowWildcards && containsWildcards(origPath) {
 		var copyInfos []copyInfo
-		if err := b.context.Walk("", func(path string, info builder.FileInfo, err error) error {
+		if err := context.Walk("", func(path string, info builder.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
@@ -321,7 +338,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 
 			// Note we set allowWildcards to false in case the name has
 			// a * in it
-			subInfos, err := b.calcCopyInfo(cmdName, path, allowLocalDecompression, false)
+			subInfos, err := b.calcCopyInfo(context, cmdName, path, allowLocalDecompression, false)
 			if err != nil {
 				return err
 			}
@@ -335,7 +352,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 
 	// Must be a dir or a file
 
-	statPath, fi, err := b.context.Stat(origPath)
+	statPath, fi, err := context.Stat(origPath)
 	if err != nil {
 		return nil, err
 	}
@@ -354,7 +371,7 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	}
 	// Must be a dir
 	var subfiles []string
-	err = b.context.Walk(statPath, func(path string, info builder.FileInfo, err error) error {
+	err = context.Walk(statPath, func(path string, info builder.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -374,6 +391,37 @@ func (b *Builder) calcCopyInfo(cmdName, origPath string, allowLocalDecompression
 	return copyInfos, nil
 }
 
+// getImage returns the image referenced by name, pulling it if it is not
+// available locally or if the build asked to always pull.
+func (b *Builder) getImage(name string) (builder.Image, error) {
+	var image builder.Image
+	// TODO: don't use `name`, instead resolve it to a digest
+	if !b.options.PullParent {
+		image, _ = b.docker.GetImageOnBuild(name)
+		// TODO: shouldn't we error out if error is different from "not found" ?
+	}
+	if image == nil {
+		var err error
+		image, err = b.docker.PullOnBuild(b.clientCtx, name, b.options.AuthConfigs, b.Output)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return image, nil
+}
+
+// getImageSource returns the image context referenced by the --from flag of
+// COPY and ADD, or nil if the flag was not used.
+func (b *Builder) getImageSource(flFrom *Flag) (*imageMount, error) {
+	if !flFrom.IsUsed() {
+		return nil, nil
+	}
+	if flFrom.Value == "" {
+		return nil, fmt.Errorf("--from requires a build stage name, index or image")
+	}
+	return b.imageContexts.get(flFrom.Value)
+}
+
 func (b *Builder) processImageFrom(img builder.Image) error {
 	if img != nil {
 		b.image = img.ImageID()
@@ -556,7 +604,18 @@ func (b *Builder) run(cID string) (err error) {
 		return err
 	}
 
-	if ret, _ := b.docker.ContainerWait(cID, -1); ret != 0 {
+	waitC, err := b.docker.ContainerWait(context.Background(), cID, container.WaitConditionNotRunning)
+	if err != nil {
+		// Unable to begin waiting for container.
+		close(finished)
+		if cancelErr := <-cancelErrCh; cancelErr != nil {
+			logrus.Debugf("Build cancelled (%v) and unable to begin ContainerWait: %v", cancelErr, err)
+		}
+		return err
+	}
+
+	status := <-waitC
+	if ret := status.ExitCode(); ret != 0 {
 		close(finished)
 		if cancelErr := <-cancelErrCh; cancelErr != nil {
 			logrus.Debugf("Build cancelled (%v) and got a non-zero code from ContainerWait: %d",
@@ -594,6 +653,36 @@ func (b *Builder) clearTmp() {
 	}
 }
 
+// readSecrets reads the build secrets from the files of the current context
+// they were sent in, and removes these files from the context so that they
+// can't be added to the image.
+func (b *Builder) readSecrets() error {
+	if len(b.options.Secrets) == 0 {
+		return nil
+	}
+	modifiable, ok := b.context.(builder.ModifiableContext)
+	if !ok {
+		return fmt.Errorf("build secrets are not supported with this build context")
+	}
+	b.secrets = make(map[string][]byte, len(b.options.Secrets))
+	for id, path := range b.options.Secrets {
+		f, err := modifiable.Open(path)
+		if err != nil {
+			return fmt.Errorf("unable to read build secret %s: %v", id, err)
+		}
+		data, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("unable to read build secret %s: %v", id, err)
+		}
+		if err := modifiable.Remove(path); err != nil {
+			return fmt.Errorf("unable to remove build secret %s from the context: %v", id, err)
+		}
+		b.secrets[id] = data
+	}
+	return nil
+}
+
 // readDockerfile reads a Dockerfile from the current context.
 func (b *Builder) readDockerfile() error {
 	// If no -f was specified then look for 'Dockerfile'. If we can't find
@@ -0,0 +1,105 @@
+package dockerfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/backend"
+)
+
+// parseRunSecrets parses the `--mount=type=secret,...` flags of a RUN
+// instruction and resolves them against the secrets passed to the build.
+// Secrets that are not marked as required and were not passed to the build
+// are skipped.
+func parseRunSecrets(mounts []string, available map[string][]byte) ([]backend.BuildSecret, error) {
+	var secrets []backend.BuildSecret
+	targets := make(map[string]struct{})
+
+	for _, value := range mounts {
+		csvReader := csv.NewReader(strings.NewReader(value))
+		fields, err := csvReader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("invalid mount %q: %v", value, err)
+		}
+
+		secret := backend.BuildSecret{
+			UID:  "0",
+			GID:  "0",
+			Mode: 0400,
+		}
+		mountType := ""
+		required := false
+
+		for _, field := range fields {
+			parts := strings.SplitN(field, "=", 2)
+			key := strings.ToLower(parts[0])
+
+			if len(parts) == 1 {
+				if key == "required" {
+					required = true
+					continue
+				}
+				return nil, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+			}
+
+			value := parts[1]
+			switch key {
+			case "type":
+				mountType = value
+			case "id":
+				secret.ID = value
+			case "target", "dst", "destination":
+				if filepath.Base(filepath.Clean(value)) != value {
+					return nil, fmt.Errorf("secret target must be a file name, not a path: %s", value)
+				}
+				secret.Target = value
+			case "required":
+				if required, err = strconv.ParseBool(value); err != nil {
+					return nil, fmt.Errorf("invalid value for required: %s", value)
+				}
+			case "uid":
+				secret.UID = value
+			case "gid":
+				secret.GID = value
+			case "mode":
+				m, err := strconv.ParseUint(value, 8, 32)
+				if err != nil {
+					return nil, fmt.Errorf("invalid mode specified: %v", err)
+				}
+				secret.Mode = os.FileMode(m)
+			default:
+				return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+			}
+		}
+
+		if mountType != "secret" {
+			return nil, fmt.Errorf("unsupported mount type %q, only 'secret' is supported", mountType)
+		}
+		if secret.ID == "" {
+			return nil, fmt.Errorf("secret mount requires an id")
+		}
+		if secret.Target == "" {
+			secret.Target = secret.ID
+		}
+		if _, exists := targets[secret.Target]; exists {
+			return nil, fmt.Errorf("duplicate secret target: %s", secret.Target)
+		}
+
+		data, ok := available[secret.ID]
+		if !ok {
+			if required {
+				return nil, fmt.Errorf("secret %s is required but was not provided to the build", secret.ID)
+			}
+			continue
+		}
+		secret.Data = data
+		targets[secret.Target] = struct{}{}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,61 @@
+package dockerfile
+
+import (
+	"os"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseRunSecrets(t *testing.T) {
+	available := map[string][]byte{
+		"npmrc": []byte("token"),
+		"other": []byte("value"),
+	}
+
+	secrets, err := parseRunSecrets([]string{
+		"type=secret,id=npmrc",
+		"type=secret,id=other,target=creds,uid=1000,gid=1000,mode=0440",
+		"type=secret,id=missing",
+	}, available)
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets), 2)
+
+	assert.Equal(t, secrets[0].ID, "npmrc")
+	assert.Equal(t, secrets[0].Target, "npmrc")
+	assert.Equal(t, secrets[0].UID, "0")
+	assert.Equal(t, secrets[0].Mode, os.FileMode(0400))
+	assert.Equal(t, string(secrets[0].Data), "token")
+
+	assert.Equal(t, secrets[1].Target, "creds")
+	assert.Equal(t, secrets[1].UID, "1000")
+	assert.Equal(t, secrets[1].GID, "1000")
+	assert.Equal(t, secrets[1].Mode, os.FileMode(0440))
+}
+
+func TestParseRunSecretsErrors(t *testing.T) {
+	available := map[string][]byte{"npmrc": []byte("token")}
+
+	cases := []struct {
+		mount    string
+		expected string
+	}{
+		{"type=bind,id=npmrc", "unsupported mount type"},
+		{"id=npmrc", "unsupported mount type"},
+		{"type=secret", "requires an id"},
+		{"type=secret,id=npmrc,target=/root/.npmrc", "must be a file name"},
+		{"type=secret,id=missing,required", "is required"},
+		{"type=secret,id=missing,required=true", "is required"},
+		{"type=secret,id=npmrc,mode=abc", "invalid mode"},
+		{"type=secret,id=npmrc,foo=bar", "unexpected key"},
+		{"type=secret,id=npmrc,foo", "must be a key=value pair"},
+	}
+
+	for _, c := range cases {
+		_, err := parseRunSecrets([]string{c.mount}, available)
+		assert.Error(t, err, c.expected)
+	}
+
+	_, err := parseRunSecrets([]string{"type=secret,id=npmrc", "type=secret,id=npmrc"}, available)
+	assert.Error(t, err, "duplicate secret target")
+}
@@ -0,0 +1,162 @@
+package builder
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/pools"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/docker/pkg/tarsum"
+)
+
+// NewLazyContext creates a new build Context backed by the directory at root.
+// Unlike the tarsum based context, individual files are only hashed the first
+// time they are asked for. It is not safe to call methods of the returned
+// Context concurrently.
+func NewLazyContext(root string) (Context, error) {
+	return &lazyContext{
+		root: root,
+		sums: make(map[string]string),
+	}, nil
+}
+
+type lazyContext struct {
+	root string
+	sums map[string]string
+}
+
+// Close does nothing as the lazy context does not own its root directory.
+func (c *lazyContext) Close() error {
+	return nil
+}
+
+func (c *lazyContext) Open(path string) (io.ReadCloser, error) {
+	cleanpath, fullpath, err := c.normalize(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := os.Open(fullpath)
+	if err != nil {
+		return nil, convertPathError(err, cleanpath)
+	}
+	return r, nil
+}
+
+func (c *lazyContext) Stat(path string) (string, FileInfo, error) {
+	cleanpath, fullpath, err := c.normalize(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	st, err := os.Lstat(fullpath)
+	if err != nil {
+		return "", nil, convertPathError(err, cleanpath)
+	}
+
+	rel, err := filepath.Rel(c.root, fullpath)
+	if err != nil {
+		return "", nil, convertPathError(err, cleanpath)
+	}
+
+	sum, err := c.hash(rel, st)
+	if err != nil {
+		return "", nil, err
+	}
+	fi := &HashedFileInfo{PathFileInfo{st, fullpath, filepath.Base(cleanpath)}, sum}
+	return rel, fi, nil
+}
+
+func (c *lazyContext) Walk(root string, walkFn WalkFunc) error {
+	_, fullpath, err := c.normalize(root)
+	if err != nil {
+		return err
+	}
+	return filepath.Walk(fullpath, func(fullpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(c.root, fullpath)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		sum, err := c.hash(rel, info)
+		if err != nil {
+			return err
+		}
+		fi := &HashedFileInfo{PathFileInfo{FileInfo: info, FilePath: fullpath}, sum}
+		return walkFn(rel, fi, nil)
+	})
+}
+
+// hash returns the cached checksum of the file at rel, calculating it first
+// if needed. The checksum covers the same header fields as a version 1 tarsum
+// followed by the file content.
+func (c *lazyContext) hash(rel string, fi os.FileInfo) (string, error) {
+	if sum, ok := c.sums[rel]; ok {
+		return sum, nil
+	}
+	fullpath := filepath.Join(c.root, rel)
+	h, err := newFileHash(fullpath, rel, fi)
+	if err != nil {
+		return "", fmt.Errorf("failed to create hash for %s: %v", rel, err)
+	}
+	if fi.Mode().IsRegular() && fi.Size() > 0 {
+		f, err := os.Open(fullpath)
+		if err != nil {
+			return "", fmt.Errorf("failed to open %s: %v", rel, err)
+		}
+		defer f.Close()
+		if _, err := pools.Copy(h, f); err != nil {
+			return "", fmt.Errorf("failed to copy file data for %s: %v", rel, err)
+		}
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	c.sums[rel] = sum
+	return sum, nil
+}
+
+func (c *lazyContext) normalize(path string) (cleanpath, fullpath string, err error) {
+	cleanpath = filepath.Clean(string(os.PathSeparator) + path)[1:]
+	fullpath, err = symlink.FollowSymlinkInScope(filepath.Join(c.root, path), c.root)
+	if err != nil {
+		return "", "", fmt.Errorf("Forbidden path outside the build context: %s (%s)", path, fullpath)
+	}
+	_, err = os.Lstat(fullpath)
+	if err != nil {
+		return "", "", convertPathError(err, path)
+	}
+	return
+}
+
+// newFileHash returns a hash primed with the tarsum header of the file at path.
+func newFileHash(path, name string, fi os.FileInfo) (hash.Hash, error) {
+	hdr, err := archive.FileInfoHeader(path, name, fi)
+	if err != nil {
+		return nil, err
+	}
+	tsh := &tarsumHash{hdr: hdr, Hash: sha256.New()}
+	tsh.Reset()
+	return tsh, nil
+}
+
+type tarsumHash struct {
+	hash.Hash
+	hdr *tar.Header
+}
+
+// Reset resets the Hash to its initial state, which includes the tar header.
+func (tsh *tarsumHash) Reset() {
+	tsh.Hash.Reset()
+	tarsum.WriteV1Header(tsh.hdr, tsh.Hash)
+}
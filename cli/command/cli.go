@@ -8,14 +8,17 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/versions"
+	registrytypes "github.com/docker/docker/api/types/registry"
 	cliconfig "github.com/docker/docker/cli/config"
 	"github.com/docker/docker/cli/config/configfile"
 	"github.com/docker/docker/cli/config/credentials"
 	cliflags "github.com/docker/docker/cli/flags"
+	manifeststore "github.com/docker/docker/cli/manifest/store"
+	registryclient "github.com/docker/docker/cli/registry/client"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/dockerversion"
 	dopts "github.com/docker/docker/opts"
@@ -114,6 +117,20 @@ func addAll(to, from map[string]types.AuthConfig) {
 	}
 }
 
+// ManifestStore returns a store for local manifests
+func (cli *DockerCli) ManifestStore() manifeststore.Store {
+	return manifeststore.NewStore(filepath.Join(cliconfig.Dir(), "manifests"))
+}
+
+// RegistryClient returns a client for communicating with a Docker distribution
+// registry
+func (cli *DockerCli) RegistryClient(insecure bool) registryclient.RegistryClient {
+	resolver := func(ctx context.Context, index *registrytypes.IndexInfo) types.AuthConfig {
+		return ResolveAuthConfig(ctx, cli, index)
+	}
+	return registryclient.NewRegistryClient(resolver, UserAgent(), insecure)
+}
+
 // CredentialsStore returns a new credentials store based
 // on the settings provided in the configuration file. Empty string returns
 // the default credential store.
@@ -158,15 +175,8 @@ func (cli *DockerCli) Initialize(opts *cliflags.ClientOptions) error {
 	if ping, err := cli.client.Ping(context.Background()); err == nil {
 		cli.hasExperimental = ping.Experimental
 
-		// since the new header was added in 1.25, assume server is 1.24 if header is not present.
-		if ping.APIVersion == "" {
-			ping.APIVersion = "1.24"
-		}
-
-		// if server version is lower than the current cli, downgrade
-		if versions.LessThan(ping.APIVersion, cli.client.ClientVersion()) {
-			cli.client.UpdateClientVersion(ping.APIVersion)
-		}
+		// downgrade to the API version of the daemon if it is older than the cli
+		cli.client.NegotiateAPIVersionPing(ping)
 	}
 	return nil
 }
@@ -225,13 +235,14 @@ func getServerHost(hosts []string, tlsOptions *tlsconfig.Options) (host string,
 		return "", errors.New("Please specify only one -H")
 	}
 
-	host, err = dopts.ParseHost(tlsOptions != nil, host)
+	host, err = dopts.ParseClientHost(tlsOptions != nil, host)
 	return
 }
 
 func newHTTPClient(host string, tlsOptions *tlsconfig.Options) (*http.Client, error) {
-	if tlsOptions == nil {
-		// let the api client configure the default transport.
+	if tlsOptions == nil || strings.HasPrefix(host, "ssh://") {
+		// let the api client configure the default transport. ssh
+		// connections are secured by ssh, not TLS.
 		return nil, nil
 	}
 
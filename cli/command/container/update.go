@@ -9,6 +9,7 @@ import (
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
@@ -28,6 +29,7 @@ type updateOptions struct {
 	memorySwap         string
 	kernelMemory       string
 	restartPolicy      string
+	deviceCgroupRules  opts.ListOpts
 
 	nFlag int
 
@@ -36,7 +38,9 @@ type updateOptions struct {
 
 // NewUpdateCommand creates a new cobra.Command for `docker update`
 func NewUpdateCommand(dockerCli *command.DockerCli) *cobra.Command {
-	var opts updateOptions
+	opts := updateOptions{
+		deviceCgroupRules: opts.NewListOpts(runconfigopts.ValidateDeviceCgroupRule),
+	}
 
 	cmd := &cobra.Command{
 		Use:   "update [OPTIONS] CONTAINER [CONTAINER...]",
@@ -63,6 +67,8 @@ func NewUpdateCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.StringVar(&opts.memorySwap, "memory-swap", "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.StringVar(&opts.kernelMemory, "kernel-memory", "", "Kernel memory limit")
 	flags.StringVar(&opts.restartPolicy, "restart", "", "Restart policy to apply when a container exits")
+	flags.Var(&opts.deviceCgroupRules, "device-cgroup-rule", "Replace the rules in the cgroup allowed devices list")
+	flags.SetAnnotation("device-cgroup-rule", "version", []string{"1.26"})
 
 	return cmd
 }
@@ -131,6 +137,7 @@ func runUpdate(dockerCli *command.DockerCli, opts *updateOptions) error {
 		CPUQuota:           opts.cpuQuota,
 		CPURealtimePeriod:  opts.cpuRealtimePeriod,
 		CPURealtimeRuntime: opts.cpuRealtimeRuntime,
+		DeviceCgroupRules:  opts.deviceCgroupRules.GetAll(),
 	}
 
 	updateConfig := containertypes.UpdateConfig{
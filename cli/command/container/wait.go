@@ -6,12 +6,14 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/spf13/cobra"
 )
 
 type waitOptions struct {
+	condition  string
 	containers []string
 }
 
@@ -20,7 +22,7 @@ func NewWaitCommand(dockerCli *command.DockerCli) *cobra.Command {
 	var opts waitOptions
 
 	cmd := &cobra.Command{
-		Use:   "wait CONTAINER [CONTAINER...]",
+		Use:   "wait [OPTIONS] CONTAINER [CONTAINER...]",
 		Short: "Block until one or more containers stop, then print their exit codes",
 		Args:  cli.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -28,6 +30,10 @@ func NewWaitCommand(dockerCli *command.DockerCli) *cobra.Command {
 			return runWait(dockerCli, &opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.condition, "condition", string(container.WaitConditionNotRunning), "Condition to wait for (not-running, next-exit, removed)")
+	flags.SetAnnotation("condition", "version", []string{"1.26"})
 	return cmd
 }
 
@@ -35,12 +41,14 @@ func runWait(dockerCli *command.DockerCli, opts *waitOptions) error {
 	ctx := context.Background()
 
 	var errs []string
-	for _, container := range opts.containers {
-		status, err := dockerCli.Client().ContainerWait(ctx, container)
-		if err != nil {
+	for _, name := range opts.containers {
+		resultC, errC := dockerCli.Client().ContainerWait(ctx, name, container.WaitCondition(opts.condition))
+
+		select {
+		case result := <-resultC:
+			fmt.Fprintf(dockerCli.Out(), "%d\n", result.StatusCode)
+		case err := <-errC:
 			errs = append(errs, err.Error())
-		} else {
-			fmt.Fprintf(dockerCli.Out(), "%d\n", status)
 		}
 	}
 	if len(errs) > 0 {
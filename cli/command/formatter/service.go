@@ -190,7 +190,8 @@ func (ctx *serviceInspectContext) UpdateStatusStarted() string {
 }
 
 func (ctx *serviceInspectContext) UpdateIsCompleted() bool {
-	return ctx.Service.UpdateStatus.State == swarm.UpdateStateCompleted && ctx.Service.UpdateStatus.CompletedAt != nil
+	completed := ctx.Service.UpdateStatus.State == swarm.UpdateStateCompleted || ctx.Service.UpdateStatus.State == swarm.UpdateStateRollbackCompleted
+	return completed && ctx.Service.UpdateStatus.CompletedAt != nil
 }
 
 func (ctx *serviceInspectContext) UpdateStatusCompleted() string {
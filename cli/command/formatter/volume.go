@@ -15,6 +15,7 @@ const (
 	volumeNameHeader = "VOLUME NAME"
 	mountpointHeader = "MOUNTPOINT"
 	linksHeader      = "LINKS"
+	limitHeader      = "LIMIT"
 	// Status header ?
 )
 
@@ -119,3 +120,11 @@ func (c *volumeContext) Size() string {
 	}
 	return units.HumanSize(float64(c.v.UsageData.Size))
 }
+
+func (c *volumeContext) Limit() string {
+	c.AddHeader(limitHeader)
+	if c.v.UsageData == nil || c.v.UsageData.Limit == 0 {
+		return "N/A"
+	}
+	return units.BytesSize(float64(c.v.UsageData.Limit))
+}
@@ -39,6 +39,12 @@ func TestVolumeContext(t *testing.T) {
 		{volumeContext{
 			v: types.Volume{Labels: map[string]string{"label1": "value1", "label2": "value2"}},
 		}, "label1=value1,label2=value2", labelsHeader, ctx.Labels},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: 4096}},
+		}, "N/A", limitHeader, ctx.Limit},
+		{volumeContext{
+			v: types.Volume{UsageData: &types.VolumeUsageData{Size: 4096, Limit: 10 * 1024 * 1024}},
+		}, "10 MiB", limitHeader, ctx.Limit},
 	}
 
 	for _, c := range cases {
@@ -150,8 +156,8 @@ func TestVolumeContextWriteJSON(t *testing.T) {
 		{Driver: "bar", Name: "foobar_bar"},
 	}
 	expectedJSONs := []map[string]interface{}{
-		{"Driver": "foo", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A"},
-		{"Driver": "bar", "Labels": "", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A"},
+		{"Driver": "foo", "Labels": "", "Limit": "N/A", "Links": "N/A", "Mountpoint": "", "Name": "foobar_baz", "Scope": "", "Size": "N/A"},
+		{"Driver": "bar", "Labels": "", "Limit": "N/A", "Links": "N/A", "Mountpoint": "", "Name": "foobar_bar", "Scope": "", "Size": "N/A"},
 	}
 	out := bytes.NewBufferString("")
 	err := VolumeWrite(Context{Format: "{{json .}}", Output: out}, volumes)
@@ -6,10 +6,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/pkg/urlutil"
 	"github.com/docker/docker/reference"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
@@ -60,6 +63,8 @@ type buildOptions struct {
 	securityOpt    []string
 	networkMode    string
 	squash         bool
+	target         string
+	secrets        opts.BuildSecretOpt
 }
 
 // NewBuildCommand creates a new `docker build` command
@@ -108,6 +113,10 @@ func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVar(&options.compress, "compress", false, "Compress the build context using gzip")
 	flags.StringSliceVar(&options.securityOpt, "security-opt", []string{}, "Security options")
 	flags.StringVar(&options.networkMode, "network", "default", "Set the networking mode for the RUN instructions during build")
+	flags.StringVar(&options.target, "target", "", "Set the target build stage to build.")
+	flags.SetAnnotation("target", "version", []string{"1.26"})
+	flags.Var(&options.secrets, "secret", "Secret to expose to RUN instructions, in the format 'id=name,src=path'")
+	flags.SetAnnotation("secret", "version", []string{"1.26"})
 
 	command.AddTrustedFlags(flags, true)
 
@@ -154,6 +163,11 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		buildBuff = bytes.NewBuffer(nil)
 	}
 
+	secrets, err := readBuildSecrets(options.secrets.Value())
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case specifiedContext == "-":
 		buildCtx, relDockerfile, err = build.GetContextFromReader(dockerCli.In(), options.dockerfileName)
@@ -217,7 +231,9 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		}
 
 		compression := archive.Uncompressed
-		if options.compress {
+		// With build secrets, the context is compressed once they have
+		// been added to it.
+		if options.compress && len(secrets) == 0 {
 			compression = archive.Gzip
 		}
 		buildCtx, err = archive.TarWithOptions(contextDir, &archive.TarOptions{
@@ -242,6 +258,17 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		buildCtx = replaceDockerfileTarWrapper(ctx, buildCtx, relDockerfile, translator, &resolvedTags)
 	}
 
+	var secretPaths map[string]string
+	if len(secrets) > 0 {
+		compression := archive.Uncompressed
+		if options.compress {
+			compression = archive.Gzip
+		}
+		// Send the secrets as part of the build context, the daemon
+		// removes them from it before the build starts.
+		buildCtx, secretPaths = addSecretsTarWrapper(buildCtx, secrets, compression)
+	}
+
 	// Setup an upload progress bar
 	progressOutput := streamformatter.NewStreamFormatter().NewProgressOutput(progBuff, true)
 	if !dockerCli.Out().IsTerminal() {
@@ -307,6 +334,8 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		SecurityOpt:    options.securityOpt,
 		NetworkMode:    options.networkMode,
 		Squash:         options.squash,
+		Target:         options.target,
+		Secrets:        secretPaths,
 	}
 
 	response, err := dockerCli.Client().ImageBuild(ctx, body, buildOptions)
@@ -359,6 +388,104 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 
 type translatorFunc func(context.Context, reference.NamedTagged) (reference.Canonical, error)
 
+// readBuildSecrets reads the contents of the secret files passed with
+// `--secret`, keyed by secret ID.
+func readBuildSecrets(sources map[string]string) (map[string][]byte, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	secrets := make(map[string][]byte, len(sources))
+	for id, source := range sources {
+		data, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read build secret %s: %v", id, err)
+		}
+		secrets[id] = data
+	}
+	return secrets, nil
+}
+
+// addSecretsTarWrapper adds the given build secrets to the build context, each
+// in a file with a random name at the root of the context. It returns the
+// resulting context, compressed with the given compression, and the path of
+// the file holding each secret, keyed by secret ID.
+func addSecretsTarWrapper(inputTarStream io.ReadCloser, secrets map[string][]byte, compression archive.Compression) (io.ReadCloser, map[string]string) {
+	paths := make(map[string]string, len(secrets))
+	for id := range secrets {
+		paths[id] = ".secret." + stringid.GenerateRandomID()[:20]
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer inputTarStream.Close()
+
+		decompressed, err := archive.DecompressStream(inputTarStream)
+		if err != nil {
+			pipeWriter.CloseWithError(err)
+			return
+		}
+		defer decompressed.Close()
+
+		compressed, err := archive.CompressStream(pipeWriter, compression)
+		if err != nil {
+			pipeWriter.CloseWithError(err)
+			return
+		}
+
+		tarReader := tar.NewReader(decompressed)
+		tarWriter := tar.NewWriter(compressed)
+
+		for {
+			hdr, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+			if err := tarWriter.WriteHeader(hdr); err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+			if _, err := io.Copy(tarWriter, tarReader); err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+		}
+
+		for id, data := range secrets {
+			hdr := &tar.Header{
+				Name:     paths[id],
+				Mode:     0600,
+				Size:     int64(len(data)),
+				ModTime:  time.Now(),
+				Typeflag: tar.TypeReg,
+			}
+			if err := tarWriter.WriteHeader(hdr); err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+			if _, err := tarWriter.Write(data); err != nil {
+				pipeWriter.CloseWithError(err)
+				return
+			}
+		}
+
+		if err := tarWriter.Close(); err != nil {
+			pipeWriter.CloseWithError(err)
+			return
+		}
+		if err := compressed.Close(); err != nil {
+			pipeWriter.CloseWithError(err)
+			return
+		}
+		pipeWriter.Close()
+	}()
+
+	return pipeReader, paths
+}
+
 // validateTag checks if the given image name can be resolved.
 func validateTag(rawRepo string) (string, error) {
 	_, err := reference.ParseNamed(rawRepo)
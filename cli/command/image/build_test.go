@@ -0,0 +1,64 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestAddSecretsTarWrapper(t *testing.T) {
+	for _, compression := range []archive.Compression{archive.Uncompressed, archive.Gzip} {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		dockerfile := []byte("FROM busybox\n")
+		if err := tw.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0644, Size: int64(len(dockerfile))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(dockerfile); err != nil {
+			t.Fatal(err)
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		secrets := map[string][]byte{"npmrc": []byte("s3cr3t")}
+		wrapped, paths := addSecretsTarWrapper(ioutil.NopCloser(&buf), secrets, compression)
+		defer wrapped.Close()
+
+		decompressed, err := archive.DecompressStream(wrapped)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]string{}
+		tr := tar.NewReader(decompressed)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := ioutil.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[hdr.Name] = string(content)
+		}
+
+		if files["Dockerfile"] != string(dockerfile) {
+			t.Fatalf("Expected Dockerfile to be kept in the context, got %v", files)
+		}
+		path, ok := paths["npmrc"]
+		if !ok {
+			t.Fatalf("Expected a path for secret npmrc, got %v", paths)
+		}
+		if files[path] != "s3cr3t" {
+			t.Fatalf("Expected secret npmrc to be added to the context at %s, got %v", path, files)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestIsValidOSArch(t *testing.T) {
+	assert.Equal(t, isValidOSArch("linux", "amd64"), true)
+	assert.Equal(t, isValidOSArch("linux", "s390x"), true)
+	assert.Equal(t, isValidOSArch("windows", "amd64"), true)
+	assert.Equal(t, isValidOSArch("windows", "s390x"), false)
+	assert.Equal(t, isValidOSArch("", ""), false)
+}
+
+func TestAppendIfUnique(t *testing.T) {
+	features := appendIfUnique(nil, "sse4")
+	features = appendIfUnique(features, "sse4")
+	features = appendIfUnique(features, "win32k")
+	assert.EqualStringSlice(t, features, []string{"sse4", "win32k"})
+}
@@ -0,0 +1,149 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/manifest/store"
+	"github.com/docker/docker/cli/manifest/types"
+	registryclient "github.com/docker/docker/cli/registry/client"
+	"github.com/docker/docker/reference"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type inspectOptions struct {
+	ref      string
+	list     string
+	verbose  bool
+	insecure bool
+}
+
+// newInspectCommand creates a new `docker manifest inspect` command
+func newInspectCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] [MANIFEST_LIST] MANIFEST",
+		Short: "Display an image manifest, or manifest list",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch len(args) {
+			case 1:
+				opts.ref = args[0]
+			case 2:
+				opts.list = args[0]
+				opts.ref = args[1]
+			}
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
+	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "Output additional info including layers and platform")
+	return cmd
+}
+
+func runInspect(dockerCli *command.DockerCli, opts inspectOptions) error {
+	namedRef, err := normalizeReference(opts.ref)
+	if err != nil {
+		return err
+	}
+
+	// If list reference is provided, display the local manifest in a list
+	if opts.list != "" {
+		listRef, err := normalizeReference(opts.list)
+		if err != nil {
+			return err
+		}
+
+		imageManifest, err := dockerCli.ManifestStore().Get(listRef, namedRef)
+		if err != nil {
+			return err
+		}
+		return printManifest(dockerCli.Out(), imageManifest, opts)
+	}
+
+	// Try a local manifest list first
+	localManifestList, err := dockerCli.ManifestStore().GetList(namedRef)
+	if err == nil {
+		return printManifestList(dockerCli.Out(), namedRef, localManifestList, opts)
+	}
+	if !store.IsNotFound(err) {
+		return err
+	}
+
+	// Next try a remote manifest
+	ctx := context.Background()
+	registryClient := dockerCli.RegistryClient(opts.insecure)
+	imageManifest, err := registryClient.GetManifest(ctx, namedRef)
+	if err == nil {
+		return printManifest(dockerCli.Out(), imageManifest, opts)
+	}
+	if !registryclient.IsManifestList(err) {
+		return err
+	}
+
+	// Finally try a remote manifest list
+	manifestList, err := registryClient.GetManifestList(ctx, namedRef)
+	if err != nil {
+		return err
+	}
+	return printManifestList(dockerCli.Out(), namedRef, manifestList, opts)
+}
+
+func printManifest(out io.Writer, manifest types.ImageManifest, opts inspectOptions) error {
+	var v interface{} = manifest.SchemaV2Manifest
+	if opts.verbose {
+		v = manifest
+	}
+	return printJSON(out, v)
+}
+
+func printManifestList(out io.Writer, namedRef reference.Named, list []types.ImageManifest, opts inspectOptions) error {
+	if opts.verbose {
+		return printJSON(out, list)
+	}
+
+	descriptors, err := buildManifestDescriptors(list)
+	if err != nil {
+		return err
+	}
+	manifestList, err := manifestlist.FromDescriptors(descriptors)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build manifest list for %s", namedRef)
+	}
+	return printJSON(out, manifestList)
+}
+
+func buildManifestDescriptors(list []types.ImageManifest) ([]manifestlist.ManifestDescriptor, error) {
+	descriptors := make([]manifestlist.ManifestDescriptor, 0, len(list))
+	for _, imageManifest := range list {
+		descriptor, err := imageManifest.Descriptor()
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors, nil
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, raw, "", "\t"); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, buffer.String())
+	return nil
+}
@@ -0,0 +1,136 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/manifest/types"
+	registryclient "github.com/docker/docker/cli/registry/client"
+	"github.com/docker/docker/reference"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type pushOpts struct {
+	insecure bool
+	purge    bool
+	target   string
+}
+
+func newPushListCommand(dockerCli *command.DockerCli) *cobra.Command {
+	opts := pushOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "push [OPTIONS] MANIFEST_LIST",
+		Short: "Push a manifest list to a repository",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.target = args[0]
+			return runPush(dockerCli, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.purge, "purge", "p", false, "Remove the local manifest list after push")
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow push to an insecure registry")
+	return cmd
+}
+
+func runPush(dockerCli *command.DockerCli, opts pushOpts) error {
+	targetRef, err := normalizeReference(opts.target)
+	if err != nil {
+		return err
+	}
+
+	manifestStore := dockerCli.ManifestStore()
+	manifests, err := manifestStore.GetList(targetRef)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return errors.Errorf("%s not found", targetRef)
+	}
+
+	ctx := context.Background()
+	registryClient := dockerCli.RegistryClient(opts.insecure)
+
+	descriptors := make([]manifestlist.ManifestDescriptor, 0, len(manifests))
+	for _, imageManifest := range manifests {
+		if err := pushReferencedManifest(ctx, registryClient, targetRef, imageManifest); err != nil {
+			return err
+		}
+		descriptor, err := buildManifestDescriptor(targetRef, imageManifest)
+		if err != nil {
+			return err
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+
+	manifestList, err := manifestlist.FromDescriptors(descriptors)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build manifest list for %s", targetRef)
+	}
+	dgst, err := registryClient.PutManifest(ctx, targetRef, manifestList)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(dockerCli.Out(), dgst.String())
+
+	if opts.purge {
+		return manifestStore.Remove(targetRef)
+	}
+	return nil
+}
+
+// buildManifestDescriptor returns the descriptor for an image manifest,
+// refusing images whose os/arch can not be listed.
+func buildManifestDescriptor(targetRef reference.Named, imageManifest types.ImageManifest) (manifestlist.ManifestDescriptor, error) {
+	platform := imageManifest.Platform
+	if !isValidOSArch(platform.OS, platform.Architecture) {
+		return manifestlist.ManifestDescriptor{}, errors.Errorf(
+			"manifest %s has unsupported os/arch combination: %s/%s", imageManifest.Ref, platform.OS, platform.Architecture)
+	}
+	return imageManifest.Descriptor()
+}
+
+// pushReferencedManifest makes an image manifest, and the blobs it
+// references, available in the repository of the manifest list. Images
+// already in that repository are left untouched; images from other
+// repositories on the same registry have their blobs cross-mounted and their
+// manifest pushed by digest.
+func pushReferencedManifest(ctx context.Context, registryClient registryclient.RegistryClient, targetRef reference.Named, imageManifest types.ImageManifest) error {
+	sourceRef := imageManifest.Ref.Named
+	if sourceRef.FullName() == targetRef.FullName() {
+		return nil
+	}
+	if sourceRef.Hostname() != targetRef.Hostname() {
+		return errors.Errorf("cannot use source images from a different registry than the target image: %s != %s",
+			sourceRef.Hostname(), targetRef.Hostname())
+	}
+
+	for _, blob := range imageManifest.Blobs() {
+		mountRef, err := reference.WithDigest(reference.TrimNamed(sourceRef), blob)
+		if err != nil {
+			return err
+		}
+		if err := registryClient.MountBlob(ctx, mountRef, reference.TrimNamed(targetRef)); err != nil {
+			return err
+		}
+	}
+
+	manifestRef, err := reference.WithDigest(reference.TrimNamed(targetRef), imageManifest.Digest)
+	if err != nil {
+		return err
+	}
+	dgst, err := registryClient.PutManifest(ctx, manifestRef, imageManifest.SchemaV2Manifest)
+	if err != nil {
+		return err
+	}
+	if dgst != imageManifest.Digest {
+		return errors.Errorf("registry returned digest %s for manifest %s, expected %s", dgst, imageManifest.Ref, imageManifest.Digest)
+	}
+	return nil
+}
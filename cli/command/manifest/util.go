@@ -0,0 +1,75 @@
+package manifest
+
+import (
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/manifest/store"
+	"github.com/docker/docker/cli/manifest/types"
+	"github.com/docker/docker/reference"
+	"golang.org/x/net/context"
+)
+
+type osArch struct {
+	os   string
+	arch string
+}
+
+// validOSArches lists the os/arch combinations accepted by annotate. It
+// follows the "Optional Environment Variables" section of
+// https://golang.org/doc/install/source, with linux/s390x added.
+var validOSArches = map[osArch]bool{
+	{os: "darwin", arch: "386"}:      true,
+	{os: "darwin", arch: "amd64"}:    true,
+	{os: "darwin", arch: "arm"}:      true,
+	{os: "darwin", arch: "arm64"}:    true,
+	{os: "dragonfly", arch: "amd64"}: true,
+	{os: "freebsd", arch: "386"}:     true,
+	{os: "freebsd", arch: "amd64"}:   true,
+	{os: "freebsd", arch: "arm"}:     true,
+	{os: "linux", arch: "386"}:       true,
+	{os: "linux", arch: "amd64"}:     true,
+	{os: "linux", arch: "arm"}:       true,
+	{os: "linux", arch: "arm64"}:     true,
+	{os: "linux", arch: "ppc64le"}:   true,
+	{os: "linux", arch: "mips64"}:    true,
+	{os: "linux", arch: "mips64le"}:  true,
+	{os: "linux", arch: "s390x"}:     true,
+	{os: "netbsd", arch: "386"}:      true,
+	{os: "netbsd", arch: "amd64"}:    true,
+	{os: "netbsd", arch: "arm"}:      true,
+	{os: "openbsd", arch: "386"}:     true,
+	{os: "openbsd", arch: "amd64"}:   true,
+	{os: "openbsd", arch: "arm"}:     true,
+	{os: "plan9", arch: "386"}:       true,
+	{os: "plan9", arch: "amd64"}:     true,
+	{os: "solaris", arch: "amd64"}:   true,
+	{os: "windows", arch: "386"}:     true,
+	{os: "windows", arch: "amd64"}:   true,
+}
+
+func isValidOSArch(os string, arch string) bool {
+	return validOSArches[osArch{os, arch}]
+}
+
+// normalizeReference parses a reference and adds the default "latest" tag
+// if neither a tag nor a digest is given.
+func normalizeReference(ref string) (reference.Named, error) {
+	namedRef, err := reference.ParseNamed(ref)
+	if err != nil {
+		return nil, err
+	}
+	return reference.WithDefaultTag(namedRef), nil
+}
+
+// getManifest from the local store, and fallback to the remote registry if it
+// doesn't exist locally
+func getManifest(ctx context.Context, dockerCli *command.DockerCli, listRef, namedRef reference.Named, insecure bool) (types.ImageManifest, error) {
+	data, err := dockerCli.ManifestStore().Get(listRef, namedRef)
+	switch {
+	case store.IsNotFound(err):
+		return dockerCli.RegistryClient(insecure).GetManifest(ctx, namedRef)
+	case err != nil:
+		return types.ImageManifest{}, err
+	default:
+		return data, nil
+	}
+}
@@ -25,6 +25,7 @@ type pluginOptions struct {
 	grantPerms bool
 	disable    bool
 	args       []string
+	force      bool
 }
 
 func newInstallCommand(dockerCli *command.DockerCli) *cobra.Command {
@@ -84,51 +85,34 @@ func newRegistryService() registry.Service {
 	}
 }
 
-func runInstall(dockerCli *command.DockerCli, opts pluginOptions) error {
+func buildPullConfig(ctx context.Context, dockerCli *command.DockerCli, opts pluginOptions, cmdName string) (types.PluginInstallOptions, error) {
 	// Parse name using distribution reference package to support name
 	// containing both tag and digest. Names with both tag and digest
 	// will be treated by the daemon as a pull by digest with
 	// an alias for the tag (if no alias is provided).
 	ref, err := distreference.ParseNamed(opts.name)
 	if err != nil {
-		return err
-	}
-
-	alias := ""
-	if opts.alias != "" {
-		aref, err := reference.ParseNamed(opts.alias)
-		if err != nil {
-			return err
-		}
-		aref = reference.WithDefaultTag(aref)
-		if _, ok := aref.(reference.NamedTagged); !ok {
-			return fmt.Errorf("invalid name: %s", opts.alias)
-		}
-		alias = aref.String()
+		return types.PluginInstallOptions{}, err
 	}
-	ctx := context.Background()
 
 	index, err := getRepoIndexFromUnnormalizedRef(ref)
 	if err != nil {
-		return err
+		return types.PluginInstallOptions{}, err
 	}
 
 	remote := ref.String()
 
 	_, isCanonical := ref.(distreference.Canonical)
 	if command.IsTrusted() && !isCanonical {
-		if alias == "" {
-			alias = ref.String()
-		}
 		var nt reference.NamedTagged
 		named, err := reference.ParseNamed(ref.Name())
 		if err != nil {
-			return err
+			return types.PluginInstallOptions{}, err
 		}
 		if tagged, ok := ref.(distreference.Tagged); ok {
 			nt, err = reference.WithTag(named, tagged.Tag())
 			if err != nil {
-				return err
+				return types.PluginInstallOptions{}, err
 			}
 		} else {
 			named = reference.WithDefaultTag(named)
@@ -137,7 +121,7 @@ func runInstall(dockerCli *command.DockerCli, opts pluginOptions) error {
 
 		trusted, err := image.TrustedReference(ctx, dockerCli, nt, newRegistryService())
 		if err != nil {
-			return err
+			return types.PluginInstallOptions{}, err
 		}
 		remote = trusted.String()
 	}
@@ -146,10 +130,10 @@ func runInstall(dockerCli *command.DockerCli, opts pluginOptions) error {
 
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
-		return err
+		return types.PluginInstallOptions{}, err
 	}
 
-	registryAuthFunc := command.RegistryAuthenticationPrivilegedFunc(dockerCli, index, "plugin install")
+	registryAuthFunc := command.RegistryAuthenticationPrivilegedFunc(dockerCli, index, cmdName)
 
 	options := types.PluginInstallOptions{
 		RegistryAuth:          encodedAuth,
@@ -161,6 +145,36 @@ func runInstall(dockerCli *command.DockerCli, opts pluginOptions) error {
 		PrivilegeFunc: registryAuthFunc,
 		Args:          opts.args,
 	}
+	return options, nil
+}
+
+func runInstall(dockerCli *command.DockerCli, opts pluginOptions) error {
+	ref, err := distreference.ParseNamed(opts.name)
+	if err != nil {
+		return err
+	}
+
+	alias := ""
+	if opts.alias != "" {
+		aref, err := reference.ParseNamed(opts.alias)
+		if err != nil {
+			return err
+		}
+		aref = reference.WithDefaultTag(aref)
+		if _, ok := aref.(reference.NamedTagged); !ok {
+			return fmt.Errorf("invalid name: %s", opts.alias)
+		}
+		alias = aref.String()
+	}
+	if _, isCanonical := ref.(distreference.Canonical); command.IsTrusted() && !isCanonical && alias == "" {
+		alias = ref.String()
+	}
+
+	ctx := context.Background()
+	options, err := buildPullConfig(ctx, dockerCli, opts, "plugin install")
+	if err != nil {
+		return err
+	}
 
 	responseBody, err := dockerCli.Client().PluginInstall(ctx, alias, options)
 	if err != nil {
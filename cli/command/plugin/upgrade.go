@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func newUpgradeCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var options pluginOptions
+	cmd := &cobra.Command{
+		Use:   "upgrade [OPTIONS] PLUGIN REMOTE",
+		Short: "Upgrade an existing plugin",
+		Args:  cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.alias = args[0]
+			options.name = args[1]
+			return runUpgrade(dockerCli, options)
+		},
+		Tags: map[string]string{"version": "1.26"},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&options.grantPerms, "grant-all-permissions", false, "Grant all permissions necessary to run the plugin")
+	flags.BoolVarP(&options.force, "force", "f", false, "Upgrade the plugin even if it is in use")
+
+	command.AddTrustedFlags(flags, true)
+
+	return cmd
+}
+
+func runUpgrade(dockerCli *command.DockerCli, opts pluginOptions) error {
+	ctx := context.Background()
+
+	p, _, err := dockerCli.Client().PluginInspectWithRaw(ctx, opts.alias)
+	if err != nil {
+		return fmt.Errorf("error reading plugin data: %v", err)
+	}
+
+	options, err := buildPullConfig(ctx, dockerCli, opts, "plugin upgrade")
+	if err != nil {
+		return err
+	}
+	options.Force = opts.force
+
+	fmt.Fprintf(dockerCli.Out(), "Upgrading plugin %s to %s\n", p.Name, opts.name)
+
+	responseBody, err := dockerCli.Client().PluginUpgrade(ctx, p.Name, options)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+	if err := jsonmessage.DisplayJSONMessagesToStream(responseBody, dockerCli.Out(), nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "Upgraded plugin %s to %s\n", p.Name, opts.name) // todo: return proper values from the API for this result
+	return nil
+}
@@ -21,6 +21,7 @@ func NewServiceCommand(dockerCli *command.DockerCli) *cobra.Command {
 		newPsCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		newRollbackCommand(dockerCli),
 		newScaleCommand(dockerCli),
 		newUpdateCommand(dockerCli),
 		newLogsCommand(dockerCli),
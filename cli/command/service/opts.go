@@ -321,6 +321,9 @@ func (ldo *logDriverOptions) toLogDriver() *swarm.Driver {
 
 type healthCheckOptions struct {
 	cmd           string
+	http          string
+	httpStatus    string
+	tcp           string
 	interval      PositiveDurationOpt
 	timeout       PositiveDurationOpt
 	retries       int
@@ -330,6 +333,9 @@ type healthCheckOptions struct {
 func (opts *healthCheckOptions) toHealthConfig() (*container.HealthConfig, error) {
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := opts.cmd != "" ||
+		opts.http != "" ||
+		opts.httpStatus != "" ||
+		opts.tcp != "" ||
 		opts.interval.Value() != nil ||
 		opts.timeout.Value() != nil ||
 		opts.retries != 0
@@ -339,9 +345,9 @@ func (opts *healthCheckOptions) toHealthConfig() (*container.HealthConfig, error
 		}
 		healthConfig = &container.HealthConfig{Test: []string{"NONE"}}
 	} else if haveHealthSettings {
-		var test []string
-		if opts.cmd != "" {
-			test = []string{"CMD-SHELL", opts.cmd}
+		test, err := runconfigopts.HealthcheckTest(opts.cmd, opts.http, opts.httpStatus, opts.tcp)
+		if err != nil {
+			return nil, err
 		}
 		var interval, timeout time.Duration
 		if ptr := opts.interval.Value(); ptr != nil {
@@ -557,7 +563,7 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.Uint64Var(&opts.update.parallelism, flagUpdateParallelism, 1, "Maximum number of tasks updated simultaneously (0 to update all at once)")
 	flags.DurationVar(&opts.update.delay, flagUpdateDelay, time.Duration(0), "Delay between updates (ns|us|ms|s|m|h) (default 0s)")
 	flags.DurationVar(&opts.update.monitor, flagUpdateMonitor, time.Duration(0), "Duration after each task update to monitor for failure (ns|us|ms|s|m|h) (default 0s)")
-	flags.StringVar(&opts.update.onFailure, flagUpdateFailureAction, "pause", "Action on update failure (pause|continue)")
+	flags.StringVar(&opts.update.onFailure, flagUpdateFailureAction, "pause", "Action on update failure (pause|continue|rollback)")
 	flags.Var(&opts.update.maxFailureRatio, flagUpdateMaxFailureRatio, "Failure rate to tolerate during an update")
 
 	flags.StringVar(&opts.endpoint.mode, flagEndpointMode, "", "Endpoint mode (vip or dnsrr)")
@@ -568,6 +574,12 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.Var(&opts.logDriver.opts, flagLogOpt, "Logging driver options")
 
 	flags.StringVar(&opts.healthcheck.cmd, flagHealthCmd, "", "Command to run to check health")
+	flags.StringVar(&opts.healthcheck.http, flagHealthHTTP, "", "URL to send an HTTP GET request to, to check health")
+	flags.SetAnnotation(flagHealthHTTP, "version", []string{"1.26"})
+	flags.StringVar(&opts.healthcheck.httpStatus, flagHealthHTTPStatus, "", "Range of HTTP status codes to consider healthy (default 200-399)")
+	flags.SetAnnotation(flagHealthHTTPStatus, "version", []string{"1.26"})
+	flags.StringVar(&opts.healthcheck.tcp, flagHealthTCP, "", "Address to open a TCP connection to, to check health")
+	flags.SetAnnotation(flagHealthTCP, "version", []string{"1.26"})
 	flags.Var(&opts.healthcheck.interval, flagHealthInterval, "Time between running the check (ns|us|ms|s|m|h)")
 	flags.Var(&opts.healthcheck.timeout, flagHealthTimeout, "Maximum time to allow one check to run (ns|us|ms|s|m|h)")
 	flags.IntVar(&opts.healthcheck.retries, flagHealthRetries, 0, "Consecutive failures needed to report unhealthy")
@@ -638,6 +650,9 @@ const (
 	flagLogDriver             = "log-driver"
 	flagLogOpt                = "log-opt"
 	flagHealthCmd             = "health-cmd"
+	flagHealthHTTP            = "health-http"
+	flagHealthHTTPStatus      = "health-http-status"
+	flagHealthTCP             = "health-tcp"
 	flagHealthInterval        = "health-interval"
 	flagHealthRetries         = "health-retries"
 	flagHealthTimeout         = "health-timeout"
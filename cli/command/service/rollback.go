@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+)
+
+func newRollbackCommand(dockerCli *command.DockerCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback SERVICE",
+		Short: "Revert changes to a service's configuration",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(dockerCli, args[0])
+		},
+	}
+}
+
+func runRollback(dockerCli *command.DockerCli, serviceID string) error {
+	ctx := context.Background()
+
+	service, _, err := dockerCli.Client().ServiceInspectWithRaw(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+
+	return rollbackService(ctx, dockerCli, service, serviceID)
+}
+
+// rollbackService rolls service back to its previous specification. The
+// daemon rolls the service back itself, unless it is too old to do so, in
+// which case it is sent the previous specification instead.
+func rollbackService(ctx context.Context, dockerCli *command.DockerCli, service swarm.Service, serviceID string) error {
+	apiClient := dockerCli.Client()
+
+	spec := service.Spec
+	updateOpts := types.ServiceUpdateOptions{Rollback: "previous"}
+	if versions.LessThan(apiClient.ClientVersion(), "1.26") {
+		if service.PreviousSpec == nil {
+			return fmt.Errorf("service does not have a previous specification to roll back to")
+		}
+		spec = *service.PreviousSpec
+		updateOpts = types.ServiceUpdateOptions{RegistryAuthFrom: types.RegistryAuthFromPreviousSpec}
+	}
+
+	response, err := apiClient.ServiceUpdate(ctx, service.ID, service.Version, spec, updateOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range response.Warnings {
+		fmt.Fprintln(dockerCli.Err(), warning)
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", serviceID)
+	return nil
+}
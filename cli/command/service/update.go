@@ -90,6 +90,18 @@ func runUpdate(dockerCli *command.DockerCli, flags *pflag.FlagSet, serviceID str
 		return err
 	}
 
+	if rollback {
+		otherFlags := false
+		flags.Visit(func(f *pflag.Flag) {
+			if f.Name != "rollback" {
+				otherFlags = true
+			}
+		})
+		if !otherFlags {
+			return rollbackService(ctx, dockerCli, service, serviceID)
+		}
+	}
+
 	spec := &service.Spec
 	if rollback {
 		spec = service.PreviousSpec
@@ -435,7 +447,7 @@ func getUpdatedSecrets(apiClient client.APIClient, flags *pflag.FlagSet, secrets
 	if flags.Changed(flagSecretAdd) {
 		values := flags.Lookup(flagSecretAdd).Value.(*opts.SecretOpt).Value()
 
-		addSecrets, err := parseSecrets(apiClient, values)
+		addSecrets, err := ParseSecrets(apiClient, values)
 		if err != nil {
 			return nil, err
 		}
@@ -804,7 +816,7 @@ func updateLogDriver(flags *pflag.FlagSet, taskTemplate *swarm.TaskSpec) error {
 }
 
 func updateHealthcheck(flags *pflag.FlagSet, containerSpec *swarm.ContainerSpec) error {
-	if !anyChanged(flags, flagNoHealthcheck, flagHealthCmd, flagHealthInterval, flagHealthRetries, flagHealthTimeout) {
+	if !anyChanged(flags, flagNoHealthcheck, flagHealthCmd, flagHealthHTTP, flagHealthHTTPStatus, flagHealthTCP, flagHealthInterval, flagHealthRetries, flagHealthTimeout) {
 		return nil
 	}
 	if containerSpec.Healthcheck == nil {
@@ -815,7 +827,7 @@ func updateHealthcheck(flags *pflag.FlagSet, containerSpec *swarm.ContainerSpec)
 		return err
 	}
 	if noHealthcheck {
-		if !anyChanged(flags, flagHealthCmd, flagHealthInterval, flagHealthRetries, flagHealthTimeout) {
+		if !anyChanged(flags, flagHealthCmd, flagHealthHTTP, flagHealthHTTPStatus, flagHealthTCP, flagHealthInterval, flagHealthRetries, flagHealthTimeout) {
 			containerSpec.Healthcheck = &container.HealthConfig{
 				Test: []string{"NONE"},
 			}
@@ -837,13 +849,24 @@ func updateHealthcheck(flags *pflag.FlagSet, containerSpec *swarm.ContainerSpec)
 	if flags.Changed(flagHealthRetries) {
 		containerSpec.Healthcheck.Retries, _ = flags.GetInt(flagHealthRetries)
 	}
-	if flags.Changed(flagHealthCmd) {
+	if anyChanged(flags, flagHealthCmd, flagHealthHTTP, flagHealthHTTPStatus, flagHealthTCP) {
 		cmd, _ := flags.GetString(flagHealthCmd)
-		if cmd != "" {
-			containerSpec.Healthcheck.Test = []string{"CMD-SHELL", cmd}
-		} else {
-			containerSpec.Healthcheck.Test = nil
+		httpURL, _ := flags.GetString(flagHealthHTTP)
+		httpStatus, _ := flags.GetString(flagHealthHTTPStatus)
+		tcpAddr, _ := flags.GetString(flagHealthTCP)
+
+		// Changing only the status range keeps the URL of an existing
+		// HTTP health check.
+		test := containerSpec.Healthcheck.Test
+		if !anyChanged(flags, flagHealthCmd, flagHealthHTTP, flagHealthTCP) && len(test) > 1 && test[0] == "HTTP" {
+			httpURL = test[1]
+		}
+
+		test, err := runconfigopts.HealthcheckTest(cmd, httpURL, httpStatus, tcpAddr)
+		if err != nil {
+			return err
 		}
+		containerSpec.Healthcheck.Test = test
 	}
 	return nil
 }
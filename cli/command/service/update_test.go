@@ -283,6 +283,34 @@ func TestUpdateHealthcheckTable(t *testing.T) {
 			initial:  &container.HealthConfig{Test: []string{"CMD", "cmd1"}, Retries: 10},
 			expected: &container.HealthConfig{Test: []string{"CMD", "cmd1"}},
 		},
+		{
+			flags:    [][2]string{{"health-http", "http://localhost/"}},
+			initial:  &container.HealthConfig{Test: []string{"CMD-SHELL", "cmd1"}, Retries: 10},
+			expected: &container.HealthConfig{Test: []string{"HTTP", "http://localhost/"}, Retries: 10},
+		},
+		{
+			flags:    [][2]string{{"health-http-status", "200-299"}},
+			initial:  &container.HealthConfig{Test: []string{"HTTP", "http://localhost/"}},
+			expected: &container.HealthConfig{Test: []string{"HTTP", "http://localhost/", "200-299"}},
+		},
+		{
+			flags:    [][2]string{{"health-tcp", "localhost:6379"}},
+			initial:  &container.HealthConfig{Test: []string{"HTTP", "http://localhost/"}},
+			expected: &container.HealthConfig{Test: []string{"TCP", "localhost:6379"}},
+		},
+		{
+			flags:   [][2]string{{"health-http-status", "200-299"}},
+			initial: &container.HealthConfig{Test: []string{"CMD-SHELL", "cmd1"}},
+			err:     "--health-http-status requires --health-http",
+		},
+		{
+			flags: [][2]string{{"health-cmd", "cmd1"}, {"health-tcp", "localhost:6379"}},
+			err:   "--health-cmd, --health-http and --health-tcp are mutually exclusive",
+		},
+		{
+			flags: [][2]string{{"health-tcp", "localhost:6379"}, {"no-healthcheck", "true"}},
+			err:   "--no-healthcheck conflicts with --health-* options",
+		},
 		{
 			flags: [][2]string{{"health-cmd", "cmd1"}, {"no-healthcheck", "true"}},
 			err:   "--no-healthcheck conflicts with --health-* options",
@@ -5,24 +5,32 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 
-	"github.com/aanand/compose-file/loader"
-	composetypes "github.com/aanand/compose-file/types"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/compose/convert"
+	"github.com/docker/docker/cli/compose/loader"
+	composetypes "github.com/docker/docker/cli/compose/types"
 	dockerclient "github.com/docker/docker/client"
 )
 
 const (
 	defaultNetworkDriver = "overlay"
+
+	// networkDetachTimeout is how long pruning a network waits for the
+	// tasks of the previous version of the services to detach from it.
+	networkDetachTimeout = time.Minute
 )
 
 type deployOptions struct {
@@ -30,6 +38,10 @@ type deployOptions struct {
 	composefile      string
 	namespace        string
 	sendRegistryAuth bool
+	prune            bool
+	pruneNetworks    bool
+	waitHealthy      bool
+	waitTimeout      time.Duration
 }
 
 func newDeployCommand(dockerCli *command.DockerCli) *cobra.Command {
@@ -50,6 +62,10 @@ func newDeployCommand(dockerCli *command.DockerCli) *cobra.Command {
 	addBundlefileFlag(&opts.bundlefile, flags)
 	addComposefileFlag(&opts.composefile, flags)
 	addRegistryAuthFlag(&opts.sendRegistryAuth, flags)
+	flags.BoolVar(&opts.prune, "prune", false, "Prune services and secrets that are no longer referenced")
+	flags.BoolVar(&opts.pruneNetworks, "prune-networks", false, "Also prune networks that are no longer referenced (requires --prune)")
+	flags.BoolVar(&opts.waitHealthy, "wait-healthy", false, "Wait for the dependencies of a service to be healthy before deploying it")
+	flags.DurationVar(&opts.waitTimeout, "wait-timeout", 2*time.Minute, "Maximum time to wait for a dependency to become healthy")
 	return cmd
 }
 
@@ -61,6 +77,8 @@ func runDeploy(dockerCli *command.DockerCli, opts deployOptions) error {
 		return fmt.Errorf("Please specify either a bundle file (with --bundle-file) or a Compose file (with --compose-file).")
 	case opts.bundlefile != "" && opts.composefile != "":
 		return fmt.Errorf("You cannot specify both a bundle file and a Compose file.")
+	case opts.pruneNetworks && !opts.prune:
+		return fmt.Errorf("--prune-networks requires --prune")
 	case opts.bundlefile != "":
 		return deployBundle(ctx, dockerCli, opts)
 	default:
@@ -124,11 +142,169 @@ func deployCompose(ctx context.Context, dockerCli *command.DockerCli, opts deplo
 	if err := createNetworks(ctx, dockerCli, namespace, networks); err != nil {
 		return err
 	}
-	services, err := convert.Services(namespace, config)
+
+	secrets, err := convert.Secrets(namespace, config.Secrets)
+	if err != nil {
+		return err
+	}
+	if err := createSecrets(ctx, dockerCli, namespace, secrets); err != nil {
+		return err
+	}
+
+	services, err := convert.Services(namespace, config, dockerCli.Client())
 	if err != nil {
 		return err
 	}
-	return deployServices(ctx, dockerCli, services, namespace, opts.sendRegistryAuth)
+	return deployAndPrune(ctx, dockerCli, services, convert.ServiceDependencies(config), networks, secrets, namespace, opts)
+}
+
+// deployAndPrune deploys the services of the stack. With --prune, the
+// services of the stack that are no longer declared are removed before, and
+// the secrets (and with --prune-networks, the networks) that are no longer
+// declared after, so that the remaining services no longer reference them.
+func deployAndPrune(
+	ctx context.Context,
+	dockerCli *command.DockerCli,
+	services map[string]swarm.ServiceSpec,
+	dependencies map[string][]string,
+	networks map[string]types.NetworkCreate,
+	secrets []swarm.SecretSpec,
+	namespace convert.Namespace,
+	opts deployOptions,
+) error {
+	pruneFailed := false
+	if opts.prune {
+		failed, err := pruneServices(ctx, dockerCli, namespace, services)
+		if err != nil {
+			return err
+		}
+		pruneFailed = failed
+	}
+
+	if err := deployServices(ctx, dockerCli, services, dependencies, namespace, opts); err != nil {
+		return err
+	}
+
+	if opts.prune {
+		failed, err := pruneSecrets(ctx, dockerCli, namespace, secrets)
+		if err != nil {
+			return err
+		}
+		pruneFailed = pruneFailed || failed
+	}
+	if opts.pruneNetworks {
+		failed, err := pruneNetworks(ctx, dockerCli, namespace, networks)
+		if err != nil {
+			return err
+		}
+		pruneFailed = pruneFailed || failed
+	}
+
+	if pruneFailed {
+		return fmt.Errorf("Failed to prune some resources")
+	}
+	return nil
+}
+
+// pruneServices removes the services of the stack that are not in services,
+// and reports whether removing any of them failed.
+func pruneServices(
+	ctx context.Context,
+	dockerCli *command.DockerCli,
+	namespace convert.Namespace,
+	services map[string]swarm.ServiceSpec,
+) (bool, error) {
+	existingServices, err := getServices(ctx, dockerCli.Client(), namespace.Name())
+	if err != nil {
+		return false, err
+	}
+
+	var pruned []swarm.Service
+	for _, service := range existingServices {
+		if _, exists := services[namespace.Descope(service.Spec.Name)]; !exists {
+			pruned = append(pruned, service)
+		}
+	}
+	return removeServices(ctx, dockerCli, pruned), nil
+}
+
+// pruneNetworks removes the networks of the stack that are not in networks,
+// and reports whether removing any of them failed. Services are updated
+// asynchronously, so tasks of their previous version may still be attached
+// to the networks. Removing such a network is retried until the tasks are
+// gone, for up to networkDetachTimeout, after which the network is left in
+// place.
+func pruneNetworks(
+	ctx context.Context,
+	dockerCli *command.DockerCli,
+	namespace convert.Namespace,
+	networks map[string]types.NetworkCreate,
+) (bool, error) {
+	existingNetworks, err := getStackNetworks(ctx, dockerCli.Client(), namespace.Name())
+	if err != nil {
+		return false, err
+	}
+
+	var pruned []types.NetworkResource
+	for _, network := range existingNetworks {
+		if _, exists := networks[namespace.Descope(network.Name)]; !exists {
+			pruned = append(pruned, network)
+		}
+	}
+
+	var hasError bool
+	deadline := time.Now().Add(networkDetachTimeout)
+	for _, network := range pruned {
+		fmt.Fprintf(dockerCli.Err(), "Removing network %s\n", network.Name)
+		for {
+			err := dockerCli.Client().NetworkRemove(ctx, network.ID)
+			if err == nil {
+				break
+			}
+			if !strings.Contains(err.Error(), "is in use by") {
+				hasError = true
+				fmt.Fprintf(dockerCli.Err(), "Failed to remove network %s: %s\n", network.ID, err)
+				break
+			}
+			if time.Now().After(deadline) {
+				fmt.Fprintf(dockerCli.Err(), "Network %s is still in use, not removing it\n", network.Name)
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return hasError, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+	}
+	return hasError, nil
+}
+
+// pruneSecrets removes the secrets of the stack that are not in secrets, and
+// reports whether removing any of them failed.
+func pruneSecrets(
+	ctx context.Context,
+	dockerCli *command.DockerCli,
+	namespace convert.Namespace,
+	secrets []swarm.SecretSpec,
+) (bool, error) {
+	existingSecrets, err := getStackSecrets(ctx, dockerCli.Client(), namespace.Name())
+	if err != nil {
+		return false, err
+	}
+
+	declared := make(map[string]bool)
+	for _, secret := range secrets {
+		declared[secret.Name] = true
+	}
+
+	var pruned []swarm.Secret
+	for _, secret := range existingSecrets {
+		if !declared[secret.Spec.Name] {
+			pruned = append(pruned, secret)
+		}
+	}
+	return removeSecrets(ctx, dockerCli, pruned), nil
 }
 
 func propertyWarnings(properties map[string]string) string {
@@ -232,16 +408,61 @@ func createNetworks(
 	return nil
 }
 
+// createSecrets creates the secrets of the stack that do not exist yet.
+// Secrets cannot be updated, so an existing secret is left unchanged even if
+// the content of its file changed.
+func createSecrets(
+	ctx context.Context,
+	dockerCli *command.DockerCli,
+	namespace convert.Namespace,
+	secrets []swarm.SecretSpec,
+) error {
+	client := dockerCli.Client()
+
+	existingSecrets, err := getStackSecrets(ctx, client, namespace.Name())
+	if err != nil {
+		return err
+	}
+
+	existingSecretMap := make(map[string]swarm.Secret)
+	for _, secret := range existingSecrets {
+		existingSecretMap[secret.Spec.Name] = secret
+	}
+
+	for _, secretSpec := range secrets {
+		if _, exists := existingSecretMap[secretSpec.Name]; exists {
+			continue
+		}
+
+		fmt.Fprintf(dockerCli.Out(), "Creating secret %s\n", secretSpec.Name)
+		if _, err := client.SecretCreate(ctx, secretSpec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func deployServices(
 	ctx context.Context,
 	dockerCli *command.DockerCli,
 	services map[string]swarm.ServiceSpec,
+	dependencies map[string][]string,
 	namespace convert.Namespace,
-	sendAuth bool,
+	opts deployOptions,
 ) error {
 	apiClient := dockerCli.Client()
 	out := dockerCli.Out()
 
+	names := make([]string, 0, len(services))
+	for internalName := range services {
+		names = append(names, internalName)
+	}
+	order, err := convert.ServiceOrder(names, dependencies)
+	if err != nil {
+		return err
+	}
+
 	existingServices, err := getServices(ctx, apiClient, namespace.Name())
 	if err != nil {
 		return err
@@ -252,11 +473,29 @@ func deployServices(
 		existingServiceMap[service.Spec.Name] = service
 	}
 
-	for internalName, serviceSpec := range services {
+	// serviceIDs tracks the services that have been deployed so far, and
+	// healthy the ones that have been seen healthy.
+	serviceIDs := make(map[string]string)
+	healthy := make(map[string]bool)
+
+	for _, internalName := range order {
+		serviceSpec := services[internalName]
 		name := namespace.Scope(internalName)
 
+		if opts.waitHealthy {
+			for _, dependency := range dependencies[internalName] {
+				if healthy[dependency] {
+					continue
+				}
+				if err := waitOnService(ctx, dockerCli, serviceIDs[dependency], namespace.Scope(dependency), opts.waitTimeout); err != nil {
+					return err
+				}
+				healthy[dependency] = true
+			}
+		}
+
 		encodedAuth := ""
-		if sendAuth {
+		if opts.sendRegistryAuth {
 			// Retrieve encoded auth token from the image reference
 			image := serviceSpec.TaskTemplate.ContainerSpec.Image
 			encodedAuth, err = command.RetrieveAuthTokenFromImage(ctx, dockerCli, image)
@@ -269,7 +508,7 @@ func deployServices(
 			fmt.Fprintf(out, "Updating service %s (id: %s)\n", name, service.ID)
 
 			updateOpts := types.ServiceUpdateOptions{}
-			if sendAuth {
+			if opts.sendRegistryAuth {
 				updateOpts.EncodedRegistryAuth = encodedAuth
 			}
 			response, err := apiClient.ServiceUpdate(
@@ -286,18 +525,114 @@ func deployServices(
 			for _, warning := range response.Warnings {
 				fmt.Fprintln(dockerCli.Err(), warning)
 			}
+			serviceIDs[internalName] = service.ID
 		} else {
 			fmt.Fprintf(out, "Creating service %s\n", name)
 
 			createOpts := types.ServiceCreateOptions{}
-			if sendAuth {
+			if opts.sendRegistryAuth {
 				createOpts.EncodedRegistryAuth = encodedAuth
 			}
-			if _, err := apiClient.ServiceCreate(ctx, serviceSpec, createOpts); err != nil {
+			response, err := apiClient.ServiceCreate(ctx, serviceSpec, createOpts)
+			if err != nil {
 				return err
 			}
+			serviceIDs[internalName] = response.ID
 		}
 	}
 
 	return nil
 }
+
+// waitOnService blocks until all tasks of the service are running, or the
+// timeout expires. Tasks of services with a health check only reach the
+// running state once their container reports healthy.
+func waitOnService(ctx context.Context, dockerCli *command.DockerCli, serviceID, name string, timeout time.Duration) error {
+	fmt.Fprintf(dockerCli.Out(), "Waiting for service %s to become healthy\n", name)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		running, desired, err := serviceTaskCounts(ctx, dockerCli, serviceID)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("timed out after %s waiting for service %s to become healthy", timeout, name)
+			}
+			return err
+		}
+		if running >= desired {
+			fmt.Fprintf(dockerCli.Out(), "Service %s is healthy (%d/%d tasks running)\n", name, running, desired)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for service %s to become healthy (%d/%d tasks running)", timeout, name, running, desired)
+		case <-ticker.C:
+		}
+	}
+}
+
+// serviceTaskCounts returns the number of running tasks of a service, and
+// the number of tasks that should be running. Only tasks running the current
+// spec of the service are counted, so that tasks left over from a previous
+// version do not make an update look healthy. An error is returned if the
+// update of the service was paused or rolled back.
+func serviceTaskCounts(ctx context.Context, dockerCli *command.DockerCli, serviceID string) (uint64, uint64, error) {
+	apiClient := dockerCli.Client()
+
+	service, _, err := apiClient.ServiceInspectWithRaw(ctx, serviceID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if service.UpdateStatus != nil {
+		switch service.UpdateStatus.State {
+		case swarm.UpdateStatePaused:
+			return 0, 0, fmt.Errorf("update of service %s was paused: %s", service.Spec.Name, service.UpdateStatus.Message)
+		case swarm.UpdateStateRollbackStarted, swarm.UpdateStateRollbackPaused, swarm.UpdateStateRollbackCompleted:
+			return 0, 0, fmt.Errorf("update of service %s was rolled back: %s", service.Spec.Name, service.UpdateStatus.Message)
+		}
+	}
+
+	taskFilter := filters.NewArgs()
+	taskFilter.Add("service", serviceID)
+	taskFilter.Add("desired-state", string(swarm.TaskStateRunning))
+	tasks, err := apiClient.TaskList(ctx, types.TaskListOptions{Filters: taskFilter})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	taskTemplate := service.Spec.TaskTemplate
+	if versions.LessThan(apiClient.ClientVersion(), "1.26") {
+		// older daemons do not report the ForceUpdate counter of tasks
+		taskTemplate.ForceUpdate = 0
+	}
+
+	var running, desired uint64
+	for _, task := range tasks {
+		if !reflect.DeepEqual(task.Spec, taskTemplate) {
+			continue
+		}
+		if task.Status.State == swarm.TaskStateRunning {
+			running++
+		}
+	}
+
+	switch {
+	case service.Spec.Mode.Replicated != nil && service.Spec.Mode.Replicated.Replicas != nil:
+		desired = *service.Spec.Mode.Replicated.Replicas
+	case service.Spec.Mode.Global != nil:
+		// a global service needs at least one task, which may not have
+		// been scheduled yet
+		desired = uint64(len(tasks))
+		if desired == 0 {
+			desired = 1
+		}
+	}
+	return running, desired, nil
+}
@@ -79,5 +79,18 @@ func deployBundle(ctx context.Context, dockerCli *command.DockerCli, opts deploy
 	if err := createNetworks(ctx, dockerCli, namespace, networks); err != nil {
 		return err
 	}
-	return deployServices(ctx, dockerCli, services, namespace, opts.sendRegistryAuth)
+
+	// bundles do not declare secrets, so the secrets of the stack are kept
+	// as they are instead of being pruned
+	var secrets []swarm.SecretSpec
+	if opts.prune {
+		existingSecrets, err := getStackSecrets(ctx, dockerCli.Client(), namespace.Name())
+		if err != nil {
+			return err
+		}
+		for _, secret := range existingSecrets {
+			secrets = append(secrets, secret.Spec)
+		}
+	}
+	return deployAndPrune(ctx, dockerCli, services, nil, networks, secrets, namespace, opts)
 }
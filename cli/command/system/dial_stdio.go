@@ -0,0 +1,77 @@
+package system
+
+import (
+	"errors"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+)
+
+// newDialStdioCommand creates a new cobra.Command for `docker system dial-stdio`
+func newDialStdioCommand(dockerCli *command.DockerCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "dial-stdio",
+		Short:  "Proxy the stdio stream to the daemon connection. Should not be invoked manually.",
+		Args:   cli.NoArgs,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDialStdio(dockerCli)
+		},
+	}
+	return cmd
+}
+
+// runDialStdio connects to the daemon and copies the standard input to the
+// connection and the connection to the standard output. It is run on the
+// remote host by clients that connect to ssh:// hosts.
+func runDialStdio(dockerCli *command.DockerCli) error {
+	dialer, ok := dockerCli.Client().(client.DialerAPIClient)
+	if !ok {
+		return errors.New("the API client does not support dialing the daemon")
+	}
+	conn, err := dialer.Dialer()()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return proxyStdio(conn, dockerCli.In(), dockerCli.Out())
+}
+
+// proxyStdio copies in to conn and conn to out until conn is closed by the
+// daemon. When in reaches the end of the stream, the write side of conn is
+// closed so that the daemon sees the end of the stream as well.
+func proxyStdio(conn io.ReadWriter, in io.Reader, out io.Writer) error {
+	inDone := make(chan error, 1)
+	outDone := make(chan error, 1)
+
+	go func() {
+		_, err := io.Copy(conn, in)
+		if cw, ok := conn.(types.CloseWriter); ok {
+			if cerr := cw.CloseWrite(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+		inDone <- err
+	}()
+	go func() {
+		_, err := io.Copy(out, conn)
+		outDone <- err
+	}()
+
+	select {
+	case err := <-inDone:
+		if err != nil {
+			return err
+		}
+		// wait for the daemon to close the connection
+		return <-outDone
+	case err := <-outDone:
+		// the daemon closed the connection, stdin does not matter anymore
+		return err
+	}
+}
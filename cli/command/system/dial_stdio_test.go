@@ -0,0 +1,96 @@
+package system
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConn is a connection to a fake daemon made of two pipes, one for each
+// direction, so that the write side can be closed on its own.
+type fakeConn struct {
+	*io.PipeReader
+	*io.PipeWriter
+}
+
+func (c fakeConn) CloseWrite() error {
+	return c.PipeWriter.Close()
+}
+
+// newFakeConn returns the client side of the connection, and the reader and
+// writer of the daemon side.
+func newFakeConn() (fakeConn, *io.PipeReader, *io.PipeWriter) {
+	toDaemon, fromClient := io.Pipe()
+	toClient, fromDaemon := io.Pipe()
+	return fakeConn{PipeReader: toClient, PipeWriter: fromClient}, toDaemon, fromDaemon
+}
+
+func runProxyStdio(conn fakeConn, in io.Reader, out io.Writer) chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- proxyStdio(conn, in, out)
+	}()
+	return done
+}
+
+func waitProxyStdio(t *testing.T, done chan error) {
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("proxyStdio did not return")
+	}
+}
+
+func TestProxyStdioCloseWriteOnEOF(t *testing.T) {
+	conn, daemonIn, daemonOut := newFakeConn()
+	var out bytes.Buffer
+	done := runProxyStdio(conn, strings.NewReader("request"), &out)
+
+	// the daemon only sees the end of the request once stdin reached EOF
+	request, err := ioutil.ReadAll(daemonIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(request) != "request" {
+		t.Fatalf("Expected the daemon to receive %q, got %q", "request", request)
+	}
+
+	// the response must still be relayed after the write side was closed
+	if _, err := daemonOut.Write([]byte("response")); err != nil {
+		t.Fatal(err)
+	}
+	daemonOut.Close()
+
+	waitProxyStdio(t, done)
+	if out.String() != "response" {
+		t.Fatalf("Expected %q on stdout, got %q", "response", out.String())
+	}
+}
+
+func TestProxyStdioDaemonClose(t *testing.T) {
+	conn, daemonIn, daemonOut := newFakeConn()
+	defer daemonIn.Close()
+
+	// stdin stays open, as it does for an interactive session
+	in, stdin := io.Pipe()
+	defer stdin.Close()
+
+	var out bytes.Buffer
+	done := runProxyStdio(conn, in, &out)
+
+	if _, err := daemonOut.Write([]byte("bye")); err != nil {
+		t.Fatal(err)
+	}
+	daemonOut.Close()
+
+	waitProxyStdio(t, done)
+	if out.String() != "bye" {
+		t.Fatalf("Expected %q on stdout, got %q", "bye", out.String())
+	}
+}
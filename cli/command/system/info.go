@@ -317,6 +317,25 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 		}
 	}
 
+	if info.RegistryConfig != nil && len(info.RegistryConfig.HostMirrors) > 0 {
+		fmt.Fprintln(dockerCli.Out(), "Registry Host Mirrors:")
+		hosts := make([]string, 0, len(info.RegistryConfig.HostMirrors))
+		for host := range info.RegistryConfig.HostMirrors {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		for _, host := range hosts {
+			fmt.Fprintf(dockerCli.Out(), " %s:\n", host)
+			for _, mirror := range info.RegistryConfig.HostMirrors[host] {
+				if mirror.Insecure {
+					fmt.Fprintf(dockerCli.Out(), "  %s (insecure)\n", mirror.URL)
+				} else {
+					fmt.Fprintf(dockerCli.Out(), "  %s\n", mirror.URL)
+				}
+			}
+		}
+	}
+
 	fmt.Fprintf(dockerCli.Out(), "Live Restore Enabled: %v\n", info.LiveRestoreEnabled)
 
 	return nil
@@ -1,9 +1,13 @@
 package convert
 
 import (
-	composetypes "github.com/aanand/compose-file/types"
+	"io/ioutil"
+	"strings"
+
 	"github.com/docker/docker/api/types"
 	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
+	composetypes "github.com/docker/docker/cli/compose/types"
 )
 
 const (
@@ -21,6 +25,11 @@ func (n Namespace) Scope(name string) string {
 	return n.name + "_" + name
 }
 
+// Descope returns the name without the namespace prefix
+func (n Namespace) Descope(name string) string {
+	return strings.TrimPrefix(name, n.name+"_")
+}
+
 // Name returns the name of the namespace
 func (n Namespace) Name() string {
 	return n.name
@@ -86,3 +95,28 @@ func Networks(namespace Namespace, networks networkMap) (map[string]types.Networ
 
 	return result, externalNetworks
 }
+
+// Secrets converts secrets from the Compose type to the engine API type.
+// External secrets are skipped, as they must already exist.
+func Secrets(namespace Namespace, secrets map[string]composetypes.SecretConfig) ([]swarm.SecretSpec, error) {
+	result := []swarm.SecretSpec{}
+	for name, secret := range secrets {
+		if secret.External.External {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(secret.File)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, swarm.SecretSpec{
+			Annotations: swarm.Annotations{
+				Name:   namespace.Scope(name),
+				Labels: AddStackLabel(namespace, secret.Labels),
+			},
+			Data: data,
+		})
+	}
+	return result, nil
+}
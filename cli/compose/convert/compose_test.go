@@ -1,11 +1,15 @@
 package convert
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
-	composetypes "github.com/aanand/compose-file/types"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
+	composetypes "github.com/docker/docker/cli/compose/types"
 	"github.com/docker/docker/pkg/testutil/assert"
 )
 
@@ -14,6 +18,11 @@ func TestNamespaceScope(t *testing.T) {
 	assert.Equal(t, scoped, "foo_bar")
 }
 
+func TestNamespaceDescope(t *testing.T) {
+	descoped := Namespace{name: "foo"}.Descope("foo_bar")
+	assert.Equal(t, descoped, "bar")
+}
+
 func TestAddStackLabel(t *testing.T) {
 	labels := map[string]string{
 		"something": "labeled",
@@ -83,3 +92,38 @@ func TestNetworks(t *testing.T) {
 	assert.DeepEqual(t, networks, expected)
 	assert.DeepEqual(t, externals, []string{"special"})
 }
+
+func TestSecrets(t *testing.T) {
+	namespace := Namespace{name: "foo"}
+
+	dir, err := ioutil.TempDir("", "convert-secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "secret_data")
+	assert.NilError(t, ioutil.WriteFile(secretFile, []byte("secret"), 0600))
+
+	source := map[string]composetypes.SecretConfig{
+		"one": {
+			File:   secretFile,
+			Labels: map[string]string{"monster": "mash"},
+		},
+		"ext": {
+			External: composetypes.External{External: true, Name: "ext"},
+		},
+	}
+
+	specs, err := Secrets(namespace, source)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, specs, []swarm.SecretSpec{
+		{
+			Annotations: swarm.Annotations{
+				Name: "foo_one",
+				Labels: map[string]string{
+					"monster":      "mash",
+					LabelNamespace: "foo",
+				},
+			},
+			Data: []byte("secret"),
+		},
+	})
+}
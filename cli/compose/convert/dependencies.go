@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	composetypes "github.com/docker/docker/cli/compose/types"
+)
+
+// ServiceDependencies returns the services that each service of the compose
+// file depends on, keyed by service name
+func ServiceDependencies(config *composetypes.Config) map[string][]string {
+	dependencies := make(map[string][]string)
+	for _, service := range config.Services {
+		if len(service.DependsOn) > 0 {
+			dependencies[service.Name] = service.DependsOn
+		}
+	}
+	return dependencies
+}
+
+// ServiceOrder returns the given service names ordered so that each service
+// comes after all of the services it depends on. Services that are not
+// related by a dependency are ordered by name.
+func ServiceOrder(names []string, dependencies map[string][]string) ([]string, error) {
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	sort.Strings(sorted)
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(names))
+	order := make([]string, 0, len(names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle between services: %s", strings.Join(append(path, name), " -> "))
+		}
+		state[name] = visiting
+
+		deps := make([]string, len(dependencies[name]))
+		copy(deps, dependencies[name])
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if !known[dep] {
+				return fmt.Errorf("service %s depends on undefined service %s", name, dep)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range sorted {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
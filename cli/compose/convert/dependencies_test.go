@@ -0,0 +1,56 @@
+package convert
+
+import (
+	"testing"
+
+	composetypes "github.com/docker/docker/cli/compose/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestServiceDependencies(t *testing.T) {
+	config := &composetypes.Config{
+		Services: []composetypes.ServiceConfig{
+			{Name: "web", DependsOn: []string{"db", "cache"}},
+			{Name: "db"},
+			{Name: "cache"},
+		},
+	}
+	expected := map[string][]string{
+		"web": {"db", "cache"},
+	}
+	assert.DeepEqual(t, ServiceDependencies(config), expected)
+}
+
+func TestServiceOrder(t *testing.T) {
+	names := []string{"web", "worker", "db", "cache", "proxy"}
+	dependencies := map[string][]string{
+		"proxy":  {"web"},
+		"web":    {"db", "cache"},
+		"worker": {"db"},
+	}
+	order, err := ServiceOrder(names, dependencies)
+	assert.NilError(t, err)
+	assert.EqualStringSlice(t, order, []string{"cache", "db", "web", "proxy", "worker"})
+}
+
+func TestServiceOrderNoDependencies(t *testing.T) {
+	order, err := ServiceOrder([]string{"b", "c", "a"}, nil)
+	assert.NilError(t, err)
+	assert.EqualStringSlice(t, order, []string{"a", "b", "c"})
+}
+
+func TestServiceOrderUndefinedDependency(t *testing.T) {
+	_, err := ServiceOrder([]string{"web"}, map[string][]string{"web": {"db"}})
+	assert.Error(t, err, "service web depends on undefined service db")
+}
+
+func TestServiceOrderCycle(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	dependencies := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	}
+	_, err := ServiceOrder(names, dependencies)
+	assert.Error(t, err, "dependency cycle between services: a -> b -> c -> a")
+}
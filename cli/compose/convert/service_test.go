@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
-	composetypes "github.com/aanand/compose-file/types"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/swarm"
+	composetypes "github.com/docker/docker/cli/compose/types"
 	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
 )
 
 func TestConvertRestartPolicyFromNone(t *testing.T) {
@@ -178,6 +180,85 @@ func TestConvertServiceNetworks(t *testing.T) {
 	assert.DeepEqual(t, []swarm.NetworkAttachmentConfig(sortedConfigs), expected)
 }
 
+type fakeSecretClient struct {
+	secrets []swarm.Secret
+}
+
+func (c *fakeSecretClient) SecretList(ctx context.Context, options types.SecretListOptions) ([]swarm.Secret, error) {
+	return c.secrets, nil
+}
+
+func (c *fakeSecretClient) SecretCreate(ctx context.Context, secret swarm.SecretSpec) (types.SecretCreateResponse, error) {
+	return types.SecretCreateResponse{}, nil
+}
+
+func (c *fakeSecretClient) SecretRemove(ctx context.Context, id string) error {
+	return nil
+}
+
+func (c *fakeSecretClient) SecretInspectWithRaw(ctx context.Context, name string) (swarm.Secret, []byte, error) {
+	return swarm.Secret{}, nil, nil
+}
+
+func TestConvertServiceSecrets(t *testing.T) {
+	namespace := Namespace{name: "foo"}
+	client := &fakeSecretClient{
+		secrets: []swarm.Secret{
+			{ID: "ID1", Spec: swarm.SecretSpec{Annotations: swarm.Annotations{Name: "foo_super"}}},
+			{ID: "ID2", Spec: swarm.SecretSpec{Annotations: swarm.Annotations{Name: "real_name"}}},
+		},
+	}
+	secretSpecs := map[string]composetypes.SecretConfig{
+		"super": {
+			File: "/secret_data",
+		},
+		"other": {
+			External: composetypes.External{External: true, Name: "real_name"},
+		},
+	}
+	mode := uint32(0400)
+	secrets := []composetypes.ServiceSecretConfig{
+		{Source: "super"},
+		{Source: "other", Target: "target", UID: "1", GID: "2", Mode: &mode},
+	}
+
+	refs, err := convertServiceSecrets(client, namespace, secrets, secretSpecs)
+	assert.NilError(t, err)
+	sort.Sort(bySecretTargetSort(refs))
+	assert.DeepEqual(t, refs, []*swarm.SecretReference{
+		{
+			File:       &swarm.SecretReferenceFileTarget{Name: "super", UID: "0", GID: "0", Mode: 0444},
+			SecretID:   "ID1",
+			SecretName: "foo_super",
+		},
+		{
+			File:       &swarm.SecretReferenceFileTarget{Name: "target", UID: "1", GID: "2", Mode: 0400},
+			SecretID:   "ID2",
+			SecretName: "real_name",
+		},
+	})
+}
+
+func TestConvertServiceSecretsUndefined(t *testing.T) {
+	secrets := []composetypes.ServiceSecretConfig{{Source: "missing"}}
+	_, err := convertServiceSecrets(&fakeSecretClient{}, Namespace{name: "foo"}, secrets, nil)
+	assert.Error(t, err, "undefined secret \"missing\"")
+}
+
+type bySecretTargetSort []*swarm.SecretReference
+
+func (s bySecretTargetSort) Len() int {
+	return len(s)
+}
+
+func (s bySecretTargetSort) Less(i, j int) bool {
+	return strings.Compare(s[i].File.Name, s[j].File.Name) < 0
+}
+
+func (s bySecretTargetSort) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
 type byTargetSort []swarm.NetworkAttachmentConfig
 
 func (s byTargetSort) Len() int {
@@ -9,9 +9,9 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/aanand/compose-file/interpolation"
-	"github.com/aanand/compose-file/schema"
-	"github.com/aanand/compose-file/types"
+	"github.com/docker/docker/cli/compose/interpolation"
+	"github.com/docker/docker/cli/compose/schema"
+	"github.com/docker/docker/cli/compose/types"
 	"github.com/docker/docker/runconfig/opts"
 	units "github.com/docker/go-units"
 	shellwords "github.com/mattn/go-shellwords"
@@ -62,15 +62,16 @@ func Load(configDetails types.ConfigDetails) (*types.Config, error) {
 		}
 	}
 
-	if err := schema.Validate(configDict); err != nil {
+	version := schema.Version(configDict)
+	if version != "3.0" && version != "3.1" {
+		return nil, fmt.Errorf(`Unsupported Compose file version: %#v. The versions supported are "3.0" (or "3") and "3.1"`, version)
+	}
+
+	if err := schema.Validate(configDict, version); err != nil {
 		return nil, err
 	}
 
 	cfg := types.Config{}
-	version := configDict["version"].(string)
-	if version != "3" && version != "3.0" {
-		return nil, fmt.Errorf(`Unsupported Compose file version: %#v. The only version supported is "3" (or "3.0")`, version)
-	}
 
 	if services, ok := configDict["services"]; ok {
 		servicesConfig, err := interpolation.Interpolate(services.(types.Dict), "service", os.LookupEnv)
@@ -114,9 +115,25 @@ func Load(configDetails types.ConfigDetails) (*types.Config, error) {
 		cfg.Volumes = volumesMapping
 	}
 
+	if secrets, ok := configDict["secrets"]; ok {
+		secretsConfig, err := interpolation.Interpolate(secrets.(types.Dict), "secret", os.LookupEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		secretsMapping, err := loadSecrets(secretsConfig, configDetails.WorkingDir)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Secrets = secretsMapping
+	}
+
 	return &cfg, nil
 }
 
+// GetUnsupportedProperties returns the list of any unsupported properties that are
+// used in the Compose files.
 func GetUnsupportedProperties(configDetails types.ConfigDetails) []string {
 	unsupported := map[string]bool{}
 
@@ -141,6 +158,8 @@ func sortedKeys(set map[string]bool) []string {
 	return keys
 }
 
+// GetDeprecatedProperties returns the list of any deprecated properties that
+// are used in the compose files.
 func GetDeprecatedProperties(configDetails types.ConfigDetails) map[string]string {
 	return getProperties(getServices(getConfigDict(configDetails)), types.DeprecatedProperties)
 }
@@ -161,6 +180,8 @@ func getProperties(services types.Dict, propertyMap map[string]string) map[strin
 	return output
 }
 
+// ForbiddenPropertiesError is returned when there are properties in the Compose
+// file that are forbidden.
 type ForbiddenPropertiesError struct {
 	Properties map[string]string
 }
@@ -214,6 +235,8 @@ func transformHook(
 		return transformMapStringString(source, target, data)
 	case reflect.TypeOf(types.UlimitsConfig{}):
 		return transformUlimits(source, target, data)
+	case reflect.TypeOf(types.ServiceSecretConfig{}):
+		return transformServiceSecret(source, target, data)
 	case reflect.TypeOf(types.UnitBytes(0)):
 		return loadSize(data)
 	}
@@ -406,6 +429,37 @@ func loadVolumes(source types.Dict) (map[string]types.VolumeConfig, error) {
 	return volumes, nil
 }
 
+func loadSecrets(source types.Dict, workingDir string) (map[string]types.SecretConfig, error) {
+	secrets := make(map[string]types.SecretConfig)
+	if err := transform(source, &secrets); err != nil {
+		return secrets, err
+	}
+	for name, secret := range secrets {
+		switch {
+		case secret.External.External:
+			if secret.File != "" {
+				return nil, fmt.Errorf("secret %s: external and file cannot both be set", name)
+			}
+			if secret.External.Name == "" {
+				secret.External.Name = name
+			}
+		case secret.File == "":
+			return nil, fmt.Errorf("secret %s: either file or external must be set", name)
+		default:
+			secret.File = absPath(workingDir, expandUser(secret.File))
+		}
+		secrets[name] = secret
+	}
+	return secrets, nil
+}
+
+func absPath(workingDir string, filepath string) string {
+	if path.IsAbs(filepath) {
+		return filepath
+	}
+	return path.Join(workingDir, filepath)
+}
+
 func transformStruct(
 	source reflect.Type,
 	target reflect.Type,
@@ -427,6 +481,9 @@ func transformStruct(
 		fieldTag := field.Tag.Get("compose")
 
 		yamlName := toYAMLName(field.Name)
+		if tagName := field.Tag.Get("mapstructure"); tagName != "" {
+			yamlName = tagName
+		}
 		value, ok := structValue[yamlName]
 		if !ok {
 			continue
@@ -489,6 +546,23 @@ func convertField(
 	return data, nil
 }
 
+func transformServiceSecret(
+	source reflect.Type,
+	target reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	switch value := data.(type) {
+	case string:
+		return map[string]interface{}{"source": value}, nil
+	case types.Dict:
+		return map[string]interface{}(value), nil
+	case map[string]interface{}:
+		return value, nil
+	default:
+		return data, fmt.Errorf("invalid type %T for secret", value)
+	}
+}
+
 func transformExternal(
 	source reflect.Type,
 	target reflect.Type,
@@ -0,0 +1,149 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/docker/docker/cli/compose/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func buildConfigDetails(source types.Dict) types.ConfigDetails {
+	return types.ConfigDetails{
+		WorkingDir: "/work",
+		ConfigFiles: []types.ConfigFile{
+			{Filename: "filename.yml", Config: source},
+		},
+	}
+}
+
+func loadYAML(yaml string) (*types.Config, error) {
+	dict, err := ParseYAML([]byte(yaml))
+	if err != nil {
+		return nil, err
+	}
+	return Load(buildConfigDetails(dict))
+}
+
+func TestLoadMinimalV30(t *testing.T) {
+	config, err := loadYAML(`
+version: "3"
+services:
+  foo:
+    image: busybox
+`)
+	assert.NilError(t, err)
+	assert.Equal(t, len(config.Services), 1)
+	assert.Equal(t, config.Services[0].Name, "foo")
+	assert.Equal(t, config.Services[0].Image, "busybox")
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	_, err := loadYAML(`
+version: "2"
+services:
+  foo:
+    image: busybox
+`)
+	assert.Error(t, err, "Unsupported Compose file version")
+}
+
+func TestLoadSecretsRequiresV31(t *testing.T) {
+	_, err := loadYAML(`
+version: "3.0"
+services:
+  foo:
+    image: busybox
+secrets:
+  super:
+    external: true
+`)
+	assert.Error(t, err, "secrets")
+}
+
+func TestLoadSecrets(t *testing.T) {
+	config, err := loadYAML(`
+version: "3.1"
+services:
+  foo:
+    image: busybox
+    secrets:
+      - super
+      - source: other
+        target: other_target
+        uid: "103"
+        gid: "104"
+        mode: 0440
+secrets:
+  super:
+    file: ./secret_data
+    labels:
+      foo: bar
+  other:
+    external: true
+  renamed:
+    external:
+      name: real_name
+`)
+	assert.NilError(t, err)
+
+	mode := uint32(0440)
+	assert.DeepEqual(t, config.Services[0].Secrets, []types.ServiceSecretConfig{
+		{Source: "super"},
+		{Source: "other", Target: "other_target", UID: "103", GID: "104", Mode: &mode},
+	})
+	assert.DeepEqual(t, config.Secrets, map[string]types.SecretConfig{
+		"super": {
+			File:   "/work/secret_data",
+			Labels: map[string]string{"foo": "bar"},
+		},
+		"other": {
+			External: types.External{External: true, Name: "other"},
+		},
+		"renamed": {
+			External: types.External{External: true, Name: "real_name"},
+		},
+	})
+}
+
+func TestLoadSecretWithoutFileOrExternal(t *testing.T) {
+	_, err := loadYAML(`
+version: "3.1"
+services:
+  foo:
+    image: busybox
+secrets:
+  super:
+    labels:
+      foo: bar
+`)
+	assert.Error(t, err, "either file or external must be set")
+}
+
+func TestLoadSecretWithFileAndExternal(t *testing.T) {
+	_, err := loadYAML(`
+version: "3.1"
+services:
+  foo:
+    image: busybox
+secrets:
+  super:
+    file: /secret_data
+    external: true
+`)
+	assert.Error(t, err, "external and file cannot both be set")
+}
+
+func TestLoadDNS(t *testing.T) {
+	config, err := loadYAML(`
+version: "3.1"
+services:
+  foo:
+    image: busybox
+    dns: 8.8.8.8
+    dns_search:
+      - example.com
+`)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, config.Services[0].DNS, []string{"8.8.8.8"})
+	assert.DeepEqual(t, config.Services[0].DNSSearch, []string{"example.com"})
+}
@@ -1,6 +1,7 @@
 // Code generated by go-bindata.
 // sources:
 // data/config_schema_v3.0.json
+// data/config_schema_v3.1.json
 // DO NOT EDIT!
 
 package schema
@@ -88,6 +89,26 @@ func dataConfig_schema_v30Json() (*asset, error) {
 	return a, nil
 }
 
+var _dataConfig_schema_v31Json = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xed\x5a\x4b\x93\xdb\x28\x10\xbe\xeb\x57\xb8\x94\xdc\xe2\x47\xb6\x36\xb5\x55\xc9\x6d\x8f\x7b\xda\x3d\xaf\x4b\x51\x61\x09\xcb\x64\x84\x20\x80\x3c\xe3\xa4\xfc\xdf\x17\xf4\x32\xe8\x01\xc8\x56\x6a\xe6\xb0\x73\xf2\x40\xd3\xd0\xdd\x5f\x3f\x68\xf1\x33\x58\xad\xc2\xf7\x3c\x39\x41\x0c\xc2\x2f\xab\xf0\x24\x04\xfd\xb2\xdb\x7d\xe3\xa4\xd8\xd4\xa3\x5b\xc2\xb2\x5d\xca\xc0\x51\x6c\x3e\x7e\xda\xd5\x63\xef\xc2\xb5\x5a\x87\x52\xb5\x24\x21\xc5\x11\x65\x71\x3d\x13\x9f\x7f\xdf\xfe\xb6\x55\xcb\x6b\x12\x71\xa1\x50\x11\x91\xc3\x37\x98\x88\x7a\x8c\xc1\xef\x25\x62\x50\x2d\xde\x87\x67\xc8\x38\x92\xd4\xd1\x3a\x50\x73\x94\x11\x0a\x99\x40\x90\xcb\xd9\x9f\x72\x44\x8e\xb5\x24\xed\x80\xc6\x96\x0b\x86\x8a\x2c\xac\x86\xaf\x15\x07\x39\xc9\x21\x3b\xa3\x44\xe3\xd0\x1d\xf5\xdd\xee\xc6\x7f\xd7\x91\xad\xfb\x5c\xb5\xc3\x56\xe3\x14\x08\x01\x59\xf1\xcf\xf0\x6c\xd5\xf4\xd7\x3d\xd8\xfc\xf8\x73\xf3\xef\xc7\xcd\xe7\x6d\xbc\x89\x3e\xbc\x37\xa6\x95\x7e\x19\x3c\xd6\xdb\xa7\xf0\x88\x0a\x24\xa4\x34\xdd\xfe\x61\x47\x79\x6d\x7e\x5d\xbb\x8d\x41\x9a\x56\xc4\x20\x37\xf6\x3e\x82\x9c\x43\x53\xe6\x02\x8a\x67\xc2\x9e\x5c\x32\x77\x64\xaf\x24\x73\xb3\xff\x88\xcc\xa6\x38\x67\x92\x97\xd8\x69\xc1\x96\xea\x95\x84\xa9\xb7\x7f\xcc\x7e\xc1\x0d\xb4\x09\x83\xc2\x8d\xd9\x9a\xea\xd5\x20\xab\xb6\x7f\x50\xe2\xd6\xcc\x56\xda\x9a\x42\xdb\xbb\x3a\xa0\xe1\xdf\x63\xaa\x1a\xf3\xaf\x69\x5d\x75\xca\x9a\xd0\x52\x0a\x69\x4e\x2e\x6a\x6c\x42\x1f\x35\x01\x86\x85\x08\x3b\x15\xc8\x75\x87\x12\xe5\x69\x5f\xa3\xa4\x80\x7f\x2b\x16\x7b\x6d\x70\x25\x39\xf7\x42\x99\xc6\xa7\x9a\x37\xfe\x9b\x36\x78\x37\x3f\x21\x4b\x37\x2f\xa3\xb5\x80\x2f\xa2\x12\xca\xbe\x75\xad\x02\x92\x3c\x41\x76\x44\x39\xf4\x5d\x01\x58\xc6\x2d\x2a\xcb\x11\x17\x31\x61\x71\x8a\xe4\xe9\xaf\xbd\xe5\x03\x7e\x6e\x3c\xf5\xa1\xa8\xfe\xa2\x60\x84\x61\x98\x00\x1a\x4b\x76\x86\x1c\x80\x31\x70\x09\xd7\x12\x40\x02\x62\x3e\x2e\xe2\x2a\x2c\x0b\xf4\xbd\x84\x7f\x35\x24\x82\x95\xb0\xcf\x37\x95\x87\x5b\x9e\x71\xc6\x48\x49\x63\x0a\x98\x02\x98\x5d\xfd\xd2\xae\x18\x83\x62\x29\xd4\xcd\x91\xc3\x43\xf3\x12\x73\x00\x15\x90\xc5\x05\xc0\x2e\x20\x29\xaf\x83\x45\xca\xe3\x3a\xe3\x5b\x61\x74\x8c\xeb\xf5\xbc\xc7\xa0\x4b\xff\x8b\xda\x23\x2d\x6c\xc0\xae\xd9\x28\x68\xab\xb3\x85\xbd\x85\x31\x87\x80\x25\xa7\x3b\xd7\x13\x2c\xd5\xe7\xa3\x3b\x09\x14\x76\xa1\x04\xd5\x78\x79\x73\x40\x80\xc5\x39\xee\x62\xc9\x6c\x35\xc8\xd5\x88\x91\x02\xb7\xde\xe0\x13\x60\xba\x20\xaf\xd6\xbf\x50\xc2\x61\x5f\x31\x3d\x01\xf5\xa9\x4e\xd4\x60\x2c\x04\xef\x5b\xc1\xa5\x52\x8a\x12\x1f\x20\x53\x45\xac\x41\x79\x24\x0c\x03\x75\xd8\x76\xef\x60\x22\xd6\x8d\x20\x4f\x57\xa0\x2e\x83\x4a\xeb\x20\x97\xda\x29\x9e\x96\x87\xb8\x64\xcf\x40\x7c\x22\x5c\xf0\x19\x2a\xee\x96\x9f\x20\xc8\xc5\x49\x5e\x04\x92\x27\xcb\x72\x9d\xca\x58\x2d\xb7\xf5\x01\x39\xc2\x20\x73\x13\xd1\xc4\x45\x92\x83\x03\xcc\xef\x92\x73\x51\xe5\x6b\x6c\x49\x96\x29\xd2\x29\xc4\x0d\x2a\x17\xcf\x9c\x9f\x32\x24\xef\x50\xbe\x09\x9c\xd0\x5b\xc1\xb5\x1a\xfc\xb9\x0a\x10\x8f\xea\xd3\x20\xfd\xba\xad\x8b\x4f\x8b\x57\x55\xbf\xf2\x3c\x8c\xae\x23\x2c\x86\x63\xe6\x48\x4f\x42\xbf\x82\xc2\xb0\x0a\x06\x89\xaa\x1b\x18\xe4\xdc\x85\xa8\xe6\x7a\x13\x63\x92\x4e\x01\x74\x40\xcc\x7d\x23\xf5\xec\x44\x78\x5f\xfd\xe8\x65\x3a\xe7\x05\xc2\x21\xcd\xd4\xf1\xe6\xa0\xcc\x07\xfa\x37\xb3\xe7\x08\x70\xc8\xef\xab\x28\x06\xdc\x10\x3d\x7f\xf2\xc4\xc4\xd8\xda\x3f\xac\x6b\x27\x96\x4e\xf2\xf4\xaf\x91\x1d\xac\x6e\x47\xa9\xdc\x6d\xec\x20\x51\xe0\xf2\xbf\x5f\x5a\xc2\x53\x94\x4e\xc7\x8a\x2a\x42\xe8\x0e\x46\x09\x13\xfc\x75\xd2\x7d\xbd\xf5\xc3\xd9\x9e\xca\xc0\x2d\xcb\xa5\x0c\x9a\xb7\x96\x03\x21\x39\x04\x85\x11\x7a\x18\x04\xa9\x2c\x99\xf3\x8b\x07\x25\x17\x80\x39\x2f\x14\xc3\x86\xc4\x9d\xfa\x9b\x8a\x00\x6e\xaf\x19\x89\x3b\xce\xb8\xe0\x8e\x08\x21\x27\x25\x4b\xa0\xb7\xdf\x86\x52\x5b\x19\x14\xfe\xf4\xa5\x89\x52\x3b\x71\x36\x87\x78\x90\x57\x1a\x20\x5e\xdd\x6e\x19\x4c\xb9\xf1\x75\xd4\xd3\xa4\xf5\x4b\x86\xc4\x25\x96\xd5\xc0\xe2\x55\x26\x3f\xe1\x98\xa3\x1f\xd0\xf4\xe5\x5b\xb6\x6f\x18\x45\xc6\x1a\x91\xa2\x42\x9e\x06\x16\x4e\x80\x73\x41\xa8\xe4\x9f\xc9\x88\xe3\x04\xb9\x22\xcd\x18\x48\x60\x2c\x41\x83\xc8\xa8\x2d\xd6\xba\x67\xa7\x25\x03\x2a\x9a\x19\x6c\x04\xa6\xc7\x3b\xef\x86\x42\xb8\x3d\xb6\xcc\x11\x46\xd3\xae\x38\xe2\x0b\x1e\x69\xbc\x4e\xe1\xe3\x99\xdb\x92\xb5\x6f\x27\x95\x97\x4c\x19\x99\xd8\x18\x54\x2d\x85\xa3\xbd\x6e\xf4\x28\x18\x4f\x80\x99\x56\xb2\x9c\xa3\x71\xf7\xa3\x18\x5f\x10\x78\xa6\x46\xf3\x8b\x45\xc5\x6f\xdd\x1c\x24\x1a\xa5\x9f\x95\x91\xfb\xc7\x88\x26\x93\xe2\xb8\xab\x96\xdc\x59\xdb\xeb\xfd\xf4\x45\x3d\x59\x15\xb0\x0a\xd9\x29\x62\xb6\x5a\xe6\x9e\x2f\x1a\xbd\xbb\xa4\xad\xd3\xab\x93\xf6\xbb\xbd\xfb\x0e\x70\x6d\x8d\xb0\x76\xb5\x7d\x15\x3e\xd8\xd9\x08\x1e\x63\x3a\x15\x08\x43\x52\x0a\x07\x95\xcc\xa1\x0c\xf5\x34\xdf\x46\x6d\x9d\x99\x4c\xca\x6f\xb2\x65\x93\x22\x0e\x0e\xbd\xee\x6f\x17\xa3\xee\x32\xaf\xd6\x5a\x6f\x5b\x39\x36\xe3\x6a\x94\x0b\xd8\xd6\x72\x33\xd3\x4c\x46\x73\x94\x00\xee\x8a\x32\x0f\x34\x10\x4a\x9a\x02\x01\xe3\xfa\xd3\xe9\xac\xb8\x6e\x09\xe8\x14\x30\x90\xe7\x50\x6e\x8a\x7d\x02\xa4\xb4\x41\x0e\x2e\x77\x25\xbc\xba\xda\x05\x28\x2f\x19\x8c\x41\x22\x9a\xaf\xb3\x0e\x64\x4a\xe5\x4b\xc5\x10\x76\xff\x96\x18\xbc\xc4\xed\xb6\x15\x89\xab\x18\x32\xcb\x6e\xdf\xbb\xbf\x5e\x2a\x57\xb5\x22\x5f\xca\x44\xb7\x44\x3e\x81\x98\x76\xc7\x81\xe8\x72\x42\x05\xa5\xae\x35\xe3\x5c\xef\xcc\x1b\xcd\x3d\x20\xa6\x44\xa2\xfd\xb2\x94\x84\x12\xd2\xb5\x92\x7d\x00\xf1\x20\x02\x15\x1c\x54\x9d\x83\xa9\xe0\x5e\x88\x7f\x46\x45\x4a\x9e\x67\x6c\xb8\x1c\x94\x68\x2e\x8b\xcc\x5e\xbc\x7b\x54\xd1\xf2\xec\x40\x8a\x3a\x3b\xad\x3f\x2a\xd6\x03\x59\xbd\xc3\xa7\x23\xea\x77\x74\xee\x2f\xdd\x13\x91\x3e\xa1\xa5\xb3\x5f\x87\x21\x26\xec\x32\xb3\x72\xf1\x7c\x8a\xe1\x92\xb0\x25\x5b\x20\xa9\x79\xf5\x77\x1b\x2a\x75\xa1\x5b\xfc\x26\xe1\xee\xe1\x46\xee\x78\x84\x28\xc0\x4b\x39\x87\x77\xc7\x3b\x1c\x4d\xc1\x8e\x4e\x87\xa5\xdb\xb1\x5c\x93\xa2\x3c\x14\x13\x4d\x87\xc0\xe3\xd2\x32\xe7\x0a\x72\x9d\xbe\x70\x3c\x16\xf3\xda\x8f\x55\x13\x56\xdd\x77\x85\xe4\xba\xd3\x55\xe4\x6d\xe2\xc9\x2f\x45\xcb\x9d\x7f\x66\x7d\xf7\x40\x58\x6c\x9e\x12\x39\x42\x46\x43\xf5\x7f\xc4\x78\x33\xf8\xb2\xe4\xc4\x3b\x2f\x07\x33\x40\xd3\xbc\xc6\x72\x3e\x83\xaa\xa8\xee\xce\xa3\x1e\x6f\x7f\xde\x94\x21\x5e\xc5\x7f\x7b\x0d\x3e\xcd\x24\xc3\x4b\xbc\x4d\x93\xde\x1f\xa7\x02\xfd\xce\xde\x1d\xa3\x4f\x36\xf2\x62\xd6\xcc\x66\xb6\xde\x4f\x60\xff\x58\xd1\xdb\xb4\x51\xa2\x5d\xf2\x05\x23\xc8\xf6\x83\x25\x67\xdb\x3e\x22\xff\xa2\x64\xb7\x40\x5f\x6d\xdc\xa6\xbd\x3a\x3f\xe8\x1a\xf4\xfd\x47\x90\x13\xfe\xaf\xad\x1f\x3c\x89\x54\x72\x16\x97\x41\x93\xe9\xa7\xd9\xf0\xac\x9f\x33\x46\x86\x7e\x7a\x24\xf5\x93\x0c\x2d\x64\x47\xfa\xd5\x67\xca\x8c\xa3\x0f\x25\xfb\xed\xd6\xf6\xc1\x62\x64\x8f\xbb\xb7\xc7\xa5\xc1\x35\xf8\x0f\x49\xd3\xd2\x3c\xd6\x2e\x00\x00")
+
+func dataConfig_schema_v31JsonBytes() ([]byte, error) {
+	return bindataRead(
+		_dataConfig_schema_v31Json,
+		"data/config_schema_v3.1.json",
+	)
+}
+
+func dataConfig_schema_v31Json() (*asset, error) {
+	bytes, err := dataConfig_schema_v31JsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "data/config_schema_v3.1.json", size: 11990, mode: os.FileMode(420), modTime: time.Unix(1792294139, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -141,6 +162,7 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"data/config_schema_v3.0.json": dataConfig_schema_v30Json,
+	"data/config_schema_v3.1.json": dataConfig_schema_v31Json,
 }
 
 // AssetDir returns the file names below a certain
@@ -185,6 +207,7 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"data": &bintree{nil, map[string]*bintree{
 		"config_schema_v3.0.json": &bintree{dataConfig_schema_v30Json, map[string]*bintree{}},
+		"config_schema_v3.1.json": &bintree{dataConfig_schema_v31Json, map[string]*bintree{}},
 	}},
 }}
 
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+type dict map[string]interface{}
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, Version(dict{"version": "3"}), "3.0")
+	assert.Equal(t, Version(dict{"version": "3.1"}), "3.1")
+	assert.Equal(t, Version(dict{}), "1.0")
+}
+
+func TestValidate(t *testing.T) {
+	config := dict{
+		"version": "3.0",
+		"services": dict{
+			"foo": dict{
+				"image": "busybox",
+			},
+		},
+	}
+
+	assert.NilError(t, Validate(config, "3.0"))
+}
+
+func TestValidateUndefinedTopLevelOption(t *testing.T) {
+	config := dict{
+		"version": "3.0",
+		"helicopters": dict{
+			"foo": dict{
+				"image": "busybox",
+			},
+		},
+	}
+
+	assert.Error(t, Validate(config, "3.0"), "Additional property helicopters is not allowed")
+}
+
+func TestValidateSecrets(t *testing.T) {
+	config := dict{
+		"version": "3.1",
+		"services": dict{
+			"foo": dict{
+				"image":   "busybox",
+				"secrets": []interface{}{"super"},
+			},
+		},
+		"secrets": dict{
+			"super": dict{
+				"external": true,
+			},
+		},
+	}
+
+	assert.NilError(t, Validate(config, "3.1"))
+	assert.Error(t, Validate(config, "3.0"), "Additional property secrets is not allowed")
+}
+
+func TestValidateUnsupportedVersion(t *testing.T) {
+	assert.Error(t, Validate(dict{"version": "2.0"}, "2.0"), "unsupported Compose file version: 2.0")
+}
@@ -16,6 +16,8 @@ var patternString = fmt.Sprintf(
 
 var pattern = regexp.MustCompile(patternString)
 
+// InvalidTemplateError is returned when a variable template is not in a valid
+// format
 type InvalidTemplateError struct {
 	Template string
 }
@@ -24,12 +26,13 @@ func (e InvalidTemplateError) Error() string {
 	return fmt.Sprintf("Invalid template: %#v", e.Template)
 }
 
-// A user-supplied function which maps from variable names to values.
+// Mapping is a user-supplied function which maps from variable names to values.
 // Returns the value as a string and a bool indicating whether
 // the value is present, to distinguish between an empty string
 // and the absence of a value.
 type Mapping func(string) (string, bool)
 
+// Substitute variables in the string with their values
 func Substitute(template string, mapping Mapping) (result string, err *InvalidTemplateError) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -88,7 +91,6 @@ func Substitute(template string, mapping Mapping) (result string, err *InvalidTe
 		}
 
 		panic(&InvalidTemplateError{Template: template})
-		return ""
 	})
 
 	return
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/cli/manifest/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/docker/docker/reference"
+)
+
+func ref(t *testing.T, name string) reference.Named {
+	named, err := reference.ParseNamed(name)
+	assert.NilError(t, err)
+	return named
+}
+
+func sampleManifest(t *testing.T, named reference.Named, arch string) types.ImageManifest {
+	manifest, err := schema2.FromStruct(schema2.Manifest{Versioned: schema2.SchemaVersion})
+	assert.NilError(t, err)
+	imageManifest, err := types.NewImageManifest(named, manifest, manifestlist.PlatformSpec{OS: "linux", Architecture: arch})
+	assert.NilError(t, err)
+	return imageManifest
+}
+
+func newTestStore(t *testing.T) (Store, func()) {
+	tmpdir, err := ioutil.TempDir("", "manifest-store-test")
+	assert.NilError(t, err)
+	return NewStore(tmpdir), func() { os.RemoveAll(tmpdir) }
+}
+
+func TestStoreSaveAndGet(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	listRef := ref(t, "example.com/list:latest")
+	amd64Ref := ref(t, "example.com/image:amd64")
+	armRef := ref(t, "example.com/image:arm")
+	amd64 := sampleManifest(t, amd64Ref, "amd64")
+	arm := sampleManifest(t, armRef, "arm")
+
+	assert.NilError(t, store.Save(listRef, amd64Ref, amd64))
+	assert.NilError(t, store.Save(listRef, armRef, arm))
+
+	actual, err := store.Get(listRef, armRef)
+	assert.NilError(t, err)
+	assert.Equal(t, actual.Ref.String(), armRef.String())
+	assert.Equal(t, actual.Digest, arm.Digest)
+	assert.Equal(t, actual.Platform.Architecture, "arm")
+
+	list, err := store.GetList(listRef)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 2)
+}
+
+func TestStoreNotFound(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	listRef := ref(t, "example.com/list:latest")
+	_, err := store.GetList(listRef)
+	assert.Equal(t, IsNotFound(err), true)
+
+	_, err = store.Get(listRef, ref(t, "example.com/image:amd64"))
+	assert.Equal(t, IsNotFound(err), true)
+}
+
+func TestStoreRemove(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	listRef := ref(t, "example.com/list:latest")
+	imageRef := ref(t, "example.com/image:amd64")
+	assert.NilError(t, store.Save(listRef, imageRef, sampleManifest(t, imageRef, "amd64")))
+	assert.NilError(t, store.Remove(listRef))
+
+	_, err := store.GetList(listRef)
+	assert.Equal(t, IsNotFound(err), true)
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/reference"
+	"github.com/pkg/errors"
+)
+
+// ImageManifest contains info to output for a manifest object.
+type ImageManifest struct {
+	Ref              *SerializableNamed
+	Digest           digest.Digest
+	SchemaV2Manifest *schema2.DeserializedManifest `json:",omitempty"`
+	Platform         manifestlist.PlatformSpec
+}
+
+// Blobs returns the digests of all the blobs referenced from the manifest,
+// the image config first.
+func (i ImageManifest) Blobs() []digest.Digest {
+	var digests []digest.Digest
+	if i.SchemaV2Manifest == nil {
+		return digests
+	}
+	digests = append(digests, i.SchemaV2Manifest.Config.Digest)
+	for _, layer := range i.SchemaV2Manifest.Layers {
+		digests = append(digests, layer.Digest)
+	}
+	return digests
+}
+
+// Payload returns the media type and the raw payload of the manifest.
+func (i ImageManifest) Payload() (string, []byte, error) {
+	if i.SchemaV2Manifest == nil {
+		return "", nil, errors.Errorf("%s has no image manifest", i.Ref)
+	}
+	return i.SchemaV2Manifest.Payload()
+}
+
+// Descriptor returns the descriptor of the manifest as referenced from a
+// manifest list.
+func (i ImageManifest) Descriptor() (manifestlist.ManifestDescriptor, error) {
+	mediaType, payload, err := i.Payload()
+	if err != nil {
+		return manifestlist.ManifestDescriptor{}, err
+	}
+	return manifestlist.ManifestDescriptor{
+		Descriptor: distribution.Descriptor{
+			Digest:    i.Digest,
+			Size:      int64(len(payload)),
+			MediaType: mediaType,
+		},
+		Platform: i.Platform,
+	}, nil
+}
+
+// NewImageManifest returns a new ImageManifest object. The digest is
+// computed from the manifest payload.
+func NewImageManifest(ref reference.Named, manifest *schema2.DeserializedManifest, platform manifestlist.PlatformSpec) (ImageManifest, error) {
+	_, payload, err := manifest.Payload()
+	if err != nil {
+		return ImageManifest{}, err
+	}
+	return ImageManifest{
+		Ref:              &SerializableNamed{Named: ref},
+		Digest:           digest.FromBytes(payload),
+		SchemaV2Manifest: manifest,
+		Platform:         platform,
+	}, nil
+}
+
+// SerializableNamed is a reference.Named that can be serialized and
+// deserialized from JSON.
+type SerializableNamed struct {
+	reference.Named
+}
+
+// UnmarshalJSON loads the Named reference from JSON bytes
+func (s *SerializableNamed) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return errors.Wrapf(err, "invalid named reference bytes: %s", b)
+	}
+	var err error
+	s.Named, err = reference.ParseNamed(raw)
+	return err
+}
+
+// MarshalJSON returns the JSON bytes representation
+func (s *SerializableNamed) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
@@ -0,0 +1,147 @@
+// Package client provides a small client for the v2 registry API, used by
+// the CLI to read, mount and push image manifests directly, without going
+// through the daemon.
+package client
+
+import (
+	"fmt"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	distreference "github.com/docker/distribution/reference"
+	registryclient "github.com/docker/distribution/registry/client"
+	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/docker/api/types"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	manifesttypes "github.com/docker/docker/cli/manifest/types"
+	"github.com/docker/docker/reference"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// RegistryClient is a client used to communicate with a Docker distribution
+// registry
+type RegistryClient interface {
+	GetManifest(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error)
+	GetManifestList(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error)
+	MountBlob(ctx context.Context, source reference.Canonical, target reference.Named) error
+	PutManifest(ctx context.Context, ref reference.Named, manifest distribution.Manifest) (digest.Digest, error)
+}
+
+// AuthConfigResolver returns the credentials to use for a registry index
+type AuthConfigResolver func(ctx context.Context, index *registrytypes.IndexInfo) types.AuthConfig
+
+// NewRegistryClient returns a new RegistryClient with a resolver
+func NewRegistryClient(resolver AuthConfigResolver, userAgent string, insecure bool) RegistryClient {
+	return &client{
+		authConfigResolver: resolver,
+		insecureRegistry:   insecure,
+		userAgent:          userAgent,
+	}
+}
+
+type client struct {
+	authConfigResolver AuthConfigResolver
+	insecureRegistry   bool
+	userAgent          string
+}
+
+// ErrBlobCreated returned when a blob mount request was created
+type ErrBlobCreated struct {
+	From   reference.Named
+	Target reference.Named
+}
+
+func (err ErrBlobCreated) Error() string {
+	return fmt.Sprintf("blob mounted from: %v to: %v",
+		err.From, err.Target)
+}
+
+// MountBlob into the registry, so it can be referenced by a manifest
+func (c *client) MountBlob(ctx context.Context, sourceRef reference.Canonical, targetRef reference.Named) error {
+	sourceEndpoint, err := newRepositoryEndpoint(sourceRef, c.insecureRegistry)
+	if err != nil {
+		return err
+	}
+	targetEndpoint, err := newRepositoryEndpoint(targetRef, c.insecureRegistry)
+	if err != nil {
+		return err
+	}
+	sourceScope := auth.RepositoryScope{
+		Repository: sourceEndpoint.Name(),
+		Actions:    []string{"pull"},
+		Class:      sourceEndpoint.info.Class,
+	}
+	repo, err := c.getRepositoryForReference(ctx, targetRef, targetEndpoint, []string{"push", "pull"}, sourceScope)
+	if err != nil {
+		return err
+	}
+
+	sourceName, err := distreference.ParseNamed(sourceEndpoint.Name())
+	if err != nil {
+		return err
+	}
+	mountFrom, err := distreference.WithDigest(sourceName, sourceRef.Digest())
+	if err != nil {
+		return err
+	}
+	lu, err := repo.Blobs(ctx).Create(ctx, registryclient.WithMountFrom(mountFrom))
+	switch err.(type) {
+	case distribution.ErrBlobMounted:
+		return nil
+	case nil:
+	default:
+		return errors.Wrapf(err, "failed to mount blob %s to %s", sourceRef, targetRef)
+	}
+	lu.Cancel(ctx)
+	return ErrBlobCreated{From: sourceRef, Target: targetRef}
+}
+
+// PutManifest sends the manifest to a registry and returns the new digest
+func (c *client) PutManifest(ctx context.Context, ref reference.Named, manifest distribution.Manifest) (digest.Digest, error) {
+	repoEndpoint, err := newRepositoryEndpoint(ref, c.insecureRegistry)
+	if err != nil {
+		return "", err
+	}
+	repo, err := c.getRepositoryForReference(ctx, ref, repoEndpoint, []string{"push", "pull"})
+	if err != nil {
+		return "", err
+	}
+	manifestService, err := repo.Manifests(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var options []distribution.ManifestServiceOption
+	if tagged, ok := ref.(reference.NamedTagged); ok {
+		options = append(options, distribution.WithTag(tagged.Tag()))
+	}
+	dgst, err := manifestService.Put(ctx, manifest, options...)
+	return dgst, errors.Wrapf(err, "failed to put manifest %s", ref)
+}
+
+// GetManifest returns an ImageManifest for the reference
+func (c *client) GetManifest(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error) {
+	repo, err := c.getRepositoryForPull(ctx, ref)
+	if err != nil {
+		return manifesttypes.ImageManifest{}, err
+	}
+	return fetchManifest(ctx, repo, ref)
+}
+
+// GetManifestList returns a list of ImageManifest for the reference
+func (c *client) GetManifestList(ctx context.Context, ref reference.Named) ([]manifesttypes.ImageManifest, error) {
+	repo, err := c.getRepositoryForPull(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return fetchList(ctx, repo, ref)
+}
+
+func (c *client) getRepositoryForPull(ctx context.Context, ref reference.Named) (distribution.Repository, error) {
+	repoEndpoint, err := newRepositoryEndpoint(ref, c.insecureRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return c.getRepositoryForReference(ctx, ref, repoEndpoint, []string{"pull"})
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/distribution"
+	distreference "github.com/docker/distribution/reference"
+	registryclient "github.com/docker/distribution/registry/client"
+	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/distribution/registry/client/transport"
+	"github.com/docker/docker/reference"
+	"github.com/docker/docker/registry"
+	"github.com/docker/go-connections/sockets"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+type repositoryEndpoint struct {
+	info     *registry.RepositoryInfo
+	endpoint registry.APIEndpoint
+}
+
+// Name returns the repository name as known by the registry
+func (r repositoryEndpoint) Name() string {
+	repoName := r.info.FullName()
+	// If endpoint does not support CanonicalName, use the RemoteName instead
+	if r.endpoint.TrimHostname {
+		repoName = r.info.RemoteName()
+	}
+	return repoName
+}
+
+// BaseURL returns the endpoint url
+func (r repositoryEndpoint) BaseURL() string {
+	return r.endpoint.URL.String()
+}
+
+func newRepositoryEndpoint(ref reference.Named, insecure bool) (repositoryEndpoint, error) {
+	repoInfo, err := registry.ParseRepositoryInfo(ref)
+	if err != nil {
+		return repositoryEndpoint{}, err
+	}
+	endpoint, err := getDefaultEndpointFromRepoInfo(repoInfo, insecure)
+	if err != nil {
+		return repositoryEndpoint{}, err
+	}
+	return repositoryEndpoint{info: repoInfo, endpoint: endpoint}, nil
+}
+
+func getDefaultEndpointFromRepoInfo(repoInfo *registry.RepositoryInfo, insecure bool) (registry.APIEndpoint, error) {
+	var (
+		options   registry.ServiceOptions
+		indexName = repoInfo.Index.Name
+	)
+	if insecure {
+		options.InsecureRegistries = append(options.InsecureRegistries, indexName)
+	}
+	endpoints, err := registry.NewService(options).LookupPullEndpoints(indexName)
+	if err != nil {
+		return registry.APIEndpoint{}, err
+	}
+	for _, endpoint := range endpoints {
+		// Manifest lists are only supported by v2 registries; mirrors are
+		// read-only and skipped.
+		if endpoint.Version == registry.APIVersion2 && !endpoint.Mirror {
+			return endpoint, nil
+		}
+	}
+	return registry.APIEndpoint{}, errors.Errorf("no v2 endpoint found for %s", indexName)
+}
+
+// getRepositoryForReference returns a repository for the given reference,
+// authorized for the given actions. Any additional scopes are requested
+// alongside the repository's own scope.
+func (c *client) getRepositoryForReference(ctx context.Context, ref reference.Named, repoEndpoint repositoryEndpoint, actions []string, extraScopes ...auth.Scope) (distribution.Repository, error) {
+	httpTransport, err := c.getHTTPTransportForRepoEndpoint(ctx, repoEndpoint, actions, extraScopes...)
+	if err != nil {
+		return nil, err
+	}
+	repoName, err := distreference.ParseNamed(repoEndpoint.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse repo name from %s", ref)
+	}
+	return registryclient.NewRepository(ctx, repoName, repoEndpoint.BaseURL(), httpTransport)
+}
+
+func (c *client) getHTTPTransportForRepoEndpoint(ctx context.Context, repoEndpoint repositoryEndpoint, actions []string, extraScopes ...auth.Scope) (http.RoundTripper, error) {
+	authConfig := c.authConfigResolver(ctx, repoEndpoint.info.Index)
+
+	direct := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		DualStack: true,
+	}
+	base := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		Dial:                direct.Dial,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     repoEndpoint.endpoint.TLSConfig,
+		DisableKeepAlives:   true,
+	}
+	if proxyDialer, err := sockets.DialerFromEnvironment(direct); err == nil {
+		base.Dial = proxyDialer.Dial
+	}
+
+	modifiers := registry.DockerHeaders(c.userAgent, http.Header{})
+	authTransport := transport.NewTransport(base, modifiers...)
+	challengeManager, _, err := registry.PingV2Registry(repoEndpoint.endpoint.URL, authTransport)
+	if err != nil {
+		return nil, errors.Wrap(err, "error pinging v2 registry")
+	}
+
+	if authConfig.RegistryToken != "" {
+		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, &existingTokenHandler{token: authConfig.RegistryToken}))
+	} else {
+		scopes := append([]auth.Scope{auth.RepositoryScope{
+			Repository: repoEndpoint.Name(),
+			Actions:    actions,
+			Class:      repoEndpoint.info.Class,
+		}}, extraScopes...)
+		creds := registry.NewStaticCredentialStore(&authConfig)
+		tokenHandler := auth.NewTokenHandlerWithOptions(auth.TokenHandlerOptions{
+			Transport:   authTransport,
+			Credentials: creds,
+			Scopes:      scopes,
+			ClientID:    registry.AuthClientID,
+		})
+		basicHandler := auth.NewBasicHandler(creds)
+		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, tokenHandler, basicHandler))
+	}
+	return transport.NewTransport(base, modifiers...), nil
+}
+
+type existingTokenHandler struct {
+	token string
+}
+
+func (th *existingTokenHandler) Scheme() string {
+	return "bearer"
+}
+
+func (th *existingTokenHandler) AuthorizeRequest(req *http.Request, params map[string]string) error {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", th.token))
+	return nil
+}
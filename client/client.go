@@ -14,6 +14,12 @@ You use the library by creating a client object and calling methods on it. The
 client can be created either from environment variables with NewEnvClient, or
 configured manually with NewClient.
 
+When the client may talk to daemons older than itself, pass the
+WithAPIVersionNegotiation option to either constructor. The client then pings
+the daemon before its first request and downgrades to the daemon's API version
+if needed. If the daemon cannot be reached, it tries again before the next
+request. NegotiateAPIVersion does the same on demand.
+
 For example, to list running containers (the equivalent of "docker ps"):
 
 	package main
@@ -47,14 +53,19 @@ package client
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/go-connections/sockets"
 	"github.com/docker/go-connections/tlsconfig"
+	"golang.org/x/net/context"
 )
 
 // DefaultVersion is the version of the current stable API
@@ -81,14 +92,44 @@ type Client struct {
 	customHTTPHeaders map[string]string
 	// manualOverride is set to true when the version was set by users.
 	manualOverride bool
+	// negotiateVersion indicates if the client should automatically negotiate
+	// the API version to use when making requests. API version negotiation is
+	// performed the first time the version is needed, after which negotiated
+	// is set to "true" so that subsequent requests do not re-negotiate. If the
+	// negotiation fails, it is tried again by the next request.
+	negotiateVersion bool
+	// negotiated indicates that API version negotiation took place
+	negotiated bool
+	// negotiateMu makes sure that concurrent requests don't negotiate the
+	// version at the same time.
+	negotiateMu sync.Mutex
+	// versionMu protects version and negotiated.
+	versionMu sync.RWMutex
+}
+
+// Opt is a configuration option to initialize a client.
+type Opt func(*Client)
+
+// WithAPIVersionNegotiation enables automatic API version negotiation for the
+// client. With this option enabled, the client automatically negotiates the
+// API version to use when making its first request, downgrading to the API
+// version of the daemon if it is older than the client's version.
+//
+// The version is not negotiated if it was set with the DOCKER_API_VERSION
+// environment variable.
+func WithAPIVersionNegotiation() Opt {
+	return func(c *Client) {
+		c.negotiateVersion = true
+	}
 }
 
 // NewEnvClient initializes a new API client based on environment variables.
-// Use DOCKER_HOST to set the url to the docker server.
+// Use DOCKER_HOST to set the url to the docker server. An ssh://[user@]host[:port]
+// url connects to the docker server of the remote host over ssh.
 // Use DOCKER_API_VERSION to set the version of the API to reach, leave empty for latest.
 // Use DOCKER_CERT_PATH to load the TLS certificates from.
 // Use DOCKER_TLS_VERIFY to enable or disable TLS verification, off by default.
-func NewEnvClient() (*Client, error) {
+func NewEnvClient(opts ...Opt) (*Client, error) {
 	var client *http.Client
 	if dockerCertPath := os.Getenv("DOCKER_CERT_PATH"); dockerCertPath != "" {
 		options := tlsconfig.Options{
@@ -118,7 +159,7 @@ func NewEnvClient() (*Client, error) {
 		version = DefaultVersion
 	}
 
-	cli, err := NewClient(host, version, client, nil)
+	cli, err := NewClient(host, version, client, nil, opts...)
 	if err != nil {
 		return cli, err
 	}
@@ -134,8 +175,9 @@ func NewEnvClient() (*Client, error) {
 //
 // It won't send any version information if the version number is empty. It is
 // highly recommended that you set a version or your client may break if the
-// server is upgraded.
-func NewClient(host string, version string, client *http.Client, httpHeaders map[string]string) (*Client, error) {
+// server is upgraded, or use the WithAPIVersionNegotiation option to have the
+// version negotiated with the server.
+func NewClient(host string, version string, client *http.Client, httpHeaders map[string]string, opts ...Opt) (*Client, error) {
 	proto, addr, basePath, err := ParseHost(host)
 	if err != nil {
 		return nil, err
@@ -147,7 +189,16 @@ func NewClient(host string, version string, client *http.Client, httpHeaders map
 		}
 	} else {
 		transport := new(http.Transport)
-		sockets.ConfigureTransport(transport, proto, addr)
+		if proto == "ssh" {
+			if _, err := sshArgs(addr); err != nil {
+				return nil, err
+			}
+			transport.Dial = func(_, _ string) (net.Conn, error) {
+				return dialSSH(addr)
+			}
+		} else {
+			sockets.ConfigureTransport(transport, proto, addr)
+		}
 		client = &http.Client{
 			Transport: transport,
 		}
@@ -164,7 +215,7 @@ func NewClient(host string, version string, client *http.Client, httpHeaders map
 		scheme = "https"
 	}
 
-	return &Client{
+	c := &Client{
 		scheme:            scheme,
 		host:              host,
 		proto:             proto,
@@ -173,7 +224,11 @@ func NewClient(host string, version string, client *http.Client, httpHeaders map
 		client:            client,
 		version:           version,
 		customHTTPHeaders: httpHeaders,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // Close ensures that transport.Client is closed
@@ -191,10 +246,10 @@ func (cli *Client) Close() error {
 
 // getAPIPath returns the versioned request path to call the api.
 // It appends the query parameters to the path if they are not empty.
-func (cli *Client) getAPIPath(p string, query url.Values) string {
+func (cli *Client) getAPIPath(ctx context.Context, p string, query url.Values) string {
 	var apiPath string
-	if cli.version != "" {
-		v := strings.TrimPrefix(cli.version, "v")
+	if version := cli.apiVersion(ctx); version != "" {
+		v := strings.TrimPrefix(version, "v")
 		apiPath = fmt.Sprintf("%s/v%s%s", cli.basePath, v, p)
 	} else {
 		apiPath = fmt.Sprintf("%s%s", cli.basePath, p)
@@ -212,14 +267,88 @@ func (cli *Client) getAPIPath(p string, query url.Values) string {
 // ClientVersion returns the version string associated with this
 // instance of the Client. Note that this value can be changed
 // via the DOCKER_API_VERSION env var.
-// This operation doesn't acquire a mutex.
 func (cli *Client) ClientVersion() string {
+	cli.versionMu.RLock()
+	defer cli.versionMu.RUnlock()
 	return cli.version
 }
 
+// apiVersion returns the version to use for requests. If the client was
+// created with the WithAPIVersionNegotiation option, the version is
+// negotiated with the server the first time it is needed. If the server
+// cannot be reached then, the version the client was created with, or the
+// default version, is used for this request, and the version is negotiated
+// again by the next one.
+func (cli *Client) apiVersion(ctx context.Context) string {
+	if !cli.negotiateVersion {
+		return cli.ClientVersion()
+	}
+
+	cli.negotiateMu.Lock()
+	defer cli.negotiateMu.Unlock()
+
+	cli.versionMu.RLock()
+	negotiated := cli.negotiated
+	cli.versionMu.RUnlock()
+	if !negotiated {
+		ping, err := cli.Ping(ctx)
+		if err != nil {
+			if version := cli.ClientVersion(); version != "" {
+				return version
+			}
+			return DefaultVersion
+		}
+		cli.NegotiateAPIVersionPing(ping)
+	}
+	return cli.ClientVersion()
+}
+
+// NegotiateAPIVersion queries the API and updates the version to match the
+// API version. Any errors are silently ignored.
+func (cli *Client) NegotiateAPIVersion(ctx context.Context) {
+	ping, err := cli.Ping(ctx)
+	if err != nil {
+		return
+	}
+	cli.NegotiateAPIVersionPing(ping)
+}
+
+// NegotiateAPIVersionPing updates the version string associated with this
+// instance of the Client to match the API version of the server described by
+// ping, if the server is older than the client. The version is left alone if
+// it was set manually with DOCKER_API_VERSION.
+func (cli *Client) NegotiateAPIVersionPing(ping types.Ping) {
+	cli.versionMu.Lock()
+	defer cli.versionMu.Unlock()
+
+	cli.negotiated = true
+	if cli.manualOverride {
+		return
+	}
+
+	// since the API-Version header was added in 1.25, assume the server is
+	// 1.24 if the header is not present.
+	if ping.APIVersion == "" {
+		ping.APIVersion = "1.24"
+	}
+
+	// if the client was created without a version, start from the latest
+	// version supported by the client.
+	if cli.version == "" {
+		cli.version = DefaultVersion
+	}
+
+	// if the server version is lower than the client version, downgrade
+	if versions.LessThan(ping.APIVersion, cli.version) {
+		cli.version = ping.APIVersion
+	}
+}
+
 // UpdateClientVersion updates the version string associated with this
-// instance of the Client. This operation doesn't acquire a mutex.
+// instance of the Client.
 func (cli *Client) UpdateClientVersion(v string) {
+	cli.versionMu.Lock()
+	defer cli.versionMu.Unlock()
 	if !cli.manualOverride {
 		cli.version = v
 	}
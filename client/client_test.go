@@ -3,12 +3,15 @@ package client
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/docker/docker/api/types"
@@ -158,7 +161,7 @@ func TestGetAPIPath(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		g := c.getAPIPath(cs.p, cs.q)
+		g := c.getAPIPath(context.Background(), cs.p, cs.q)
 		if g != cs.e {
 			t.Fatalf("Expected %s, got %s", cs.e, g)
 		}
@@ -281,3 +284,183 @@ func TestNewEnvClientSetsDefaultVersion(t *testing.T) {
 		os.Setenv(key, envVarValues[key])
 	}
 }
+
+func TestNegotiateAPIVersionPing(t *testing.T) {
+	cases := []struct {
+		clientVersion   string
+		serverVersion   string
+		manualOverride  bool
+		expectedVersion string
+	}{
+		{clientVersion: "1.26", serverVersion: "1.24", expectedVersion: "1.24"},
+		{clientVersion: "1.24", serverVersion: "1.26", expectedVersion: "1.24"},
+		{clientVersion: "1.26", serverVersion: "", expectedVersion: "1.24"},
+		{clientVersion: "", serverVersion: "1.25", expectedVersion: "1.25"},
+		{clientVersion: "", serverVersion: "1.99", expectedVersion: DefaultVersion},
+		{clientVersion: "1.26", serverVersion: "1.24", manualOverride: true, expectedVersion: "1.26"},
+	}
+
+	for _, cs := range cases {
+		client := &Client{version: cs.clientVersion, manualOverride: cs.manualOverride}
+		client.NegotiateAPIVersionPing(types.Ping{APIVersion: cs.serverVersion})
+		if client.version != cs.expectedVersion {
+			t.Fatalf("client %q, server %q: expected version %q, got %q", cs.clientVersion, cs.serverVersion, cs.expectedVersion, client.version)
+		}
+		if !client.negotiated {
+			t.Fatalf("client %q, server %q: expected the version to be marked as negotiated", cs.clientVersion, cs.serverVersion)
+		}
+	}
+}
+
+func TestNegotiateAPIVersionAutomatic(t *testing.T) {
+	var pings int
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if req.URL.Path == "/_ping" {
+			pings++
+			header.Set("API-Version", "1.24")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("OK")),
+				Header:     header,
+			}, nil
+		}
+		if !strings.HasPrefix(req.URL.Path, "/v1.24/") {
+			return nil, fmt.Errorf("expected the negotiated version in the request path, got %s", req.URL.Path)
+		}
+		b, err := json.Marshal(types.Version{APIVersion: "1.24"})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			Header:     header,
+		}, nil
+	})
+
+	client := &Client{
+		client:  httpClient,
+		version: DefaultVersion,
+	}
+	WithAPIVersionNegotiation()(client)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ServerVersion(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.ClientVersion() != "1.24" {
+		t.Fatalf("expected version 1.24, got %s", client.ClientVersion())
+	}
+	if pings != 1 {
+		t.Fatalf("expected the version to be negotiated once, got %d pings", pings)
+	}
+}
+
+func TestNegotiateAPIVersionDisabled(t *testing.T) {
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/_ping" {
+			return nil, fmt.Errorf("unexpected ping")
+		}
+		b, err := json.Marshal(types.Version{APIVersion: DefaultVersion})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &Client{
+		client:  httpClient,
+		version: DefaultVersion,
+	}
+	if _, err := client.ServerVersion(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if client.ClientVersion() != DefaultVersion {
+		t.Fatalf("expected version %s, got %s", DefaultVersion, client.ClientVersion())
+	}
+}
+
+func TestNegotiateAPIVersionPingFailure(t *testing.T) {
+	var (
+		pings     int32
+		daemonUp  int32
+		wantedAPI = "/v" + DefaultVersion + "/"
+	)
+	httpClient := newMockClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/_ping" {
+			atomic.AddInt32(&pings, 1)
+			if atomic.LoadInt32(&daemonUp) == 0 {
+				return nil, fmt.Errorf("ping failed")
+			}
+			header := http.Header{}
+			header.Set("API-Version", "1.24")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("OK")),
+				Header:     header,
+			}, nil
+		}
+		if !strings.HasPrefix(req.URL.Path, wantedAPI) {
+			return nil, fmt.Errorf("expected %s in the request path, got %s", wantedAPI, req.URL.Path)
+		}
+		b, err := json.Marshal(types.Version{APIVersion: DefaultVersion})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}, nil
+	})
+
+	client := &Client{
+		client: httpClient,
+	}
+	WithAPIVersionNegotiation()(client)
+
+	// while the daemon can't be pinged, every request uses the default
+	// version and tries to negotiate again
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ServerVersion(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.ClientVersion() != "" {
+		t.Fatalf("expected the version not to be set, got %s", client.ClientVersion())
+	}
+	if pings != 4 {
+		t.Fatalf("expected a ping attempt per request, got %d", pings)
+	}
+
+	// once the daemon answers, the version is negotiated, only once
+	atomic.StoreInt32(&daemonUp, 1)
+	wantedAPI = "/v1.24/"
+	for i := 0; i < 2; i++ {
+		if _, err := client.ServerVersion(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.ClientVersion() != "1.24" {
+		t.Fatalf("expected version 1.24, got %s", client.ClientVersion())
+	}
+	if pings != 5 {
+		t.Fatalf("expected the version to be negotiated once, got %d pings", pings)
+	}
+}
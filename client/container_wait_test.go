@@ -20,12 +20,14 @@ func TestContainerWaitError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
 	}
-	code, err := client.ContainerWait(context.Background(), "nothing")
-	if err == nil || err.Error() != "Error response from daemon: Server error" {
-		t.Fatalf("expected a Server Error, got %v", err)
-	}
-	if code != -1 {
-		t.Fatalf("expected a status code equal to '-1', got %d", code)
+	resultC, errC := client.ContainerWait(context.Background(), "nothing", "")
+	select {
+	case result := <-resultC:
+		t.Fatalf("expected to not get a wait result, got %d", result.StatusCode)
+	case err := <-errC:
+		if err.Error() != "Error response from daemon: Server error" {
+			t.Fatalf("expected a Server Error, got %v", err)
+		}
 	}
 }
 
@@ -36,6 +38,9 @@ func TestContainerWait(t *testing.T) {
 			if !strings.HasPrefix(req.URL.Path, expectedURL) {
 				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
 			}
+			if condition := req.URL.Query().Get("condition"); condition != "next-exit" {
+				return nil, fmt.Errorf("condition not set in URL query properly. Expected 'next-exit', got %s", condition)
+			}
 			b, err := json.Marshal(container.ContainerWaitOKBody{
 				StatusCode: 15,
 			})
@@ -49,12 +54,45 @@ func TestContainerWait(t *testing.T) {
 		}),
 	}
 
-	code, err := client.ContainerWait(context.Background(), "container_id")
-	if err != nil {
+	resultC, errC := client.ContainerWait(context.Background(), "container_id", container.WaitConditionNextExit)
+	select {
+	case err := <-errC:
 		t.Fatal(err)
+	case result := <-resultC:
+		if result.StatusCode != 15 {
+			t.Fatalf("expected a status code equal to '15', got %d", result.StatusCode)
+		}
 	}
-	if code != 15 {
-		t.Fatalf("expected a status code equal to '15', got %d", code)
+}
+
+func TestContainerWaitLegacy(t *testing.T) {
+	client := &Client{
+		version: "1.25",
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if condition := req.URL.Query().Get("condition"); condition != "" {
+				return nil, fmt.Errorf("expected no condition for API 1.25, got %s", condition)
+			}
+			b, err := json.Marshal(container.ContainerWaitOKBody{
+				StatusCode: 15,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	resultC, errC := client.ContainerWait(context.Background(), "container_id", container.WaitConditionRemoved)
+	select {
+	case err := <-errC:
+		t.Fatal(err)
+	case result := <-resultC:
+		if result.StatusCode != 15 {
+			t.Fatalf("expected a status code equal to '15', got %d", result.StatusCode)
+		}
 	}
 }
 
@@ -63,8 +101,8 @@ func ExampleClient_ContainerWait_withTimeout() {
 	defer cancel()
 
 	client, _ := NewEnvClient()
-	_, err := client.ContainerWait(ctx, "container_id")
-	if err != nil {
+	_, errC := client.ContainerWait(ctx, "container_id", "")
+	if err := <-errC; err != nil {
 		log.Fatal(err)
 	}
 }
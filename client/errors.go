@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/api/types/versions"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 )
 
 // errConnectionFailed implements an error returned when connection failed.
@@ -228,12 +229,19 @@ func IsErrPluginPermissionDenied(err error) bool {
 // NewVersionError returns an error if the APIVersion required
 // if less than the current supported version
 func (cli *Client) NewVersionError(APIrequired, feature string) error {
-	if versions.LessThan(cli.version, APIrequired) {
-		return fmt.Errorf("%q requires API version %s, but the Docker server is version %s", feature, APIrequired, cli.version)
+	if version := cli.ClientVersion(); versions.LessThan(version, APIrequired) {
+		return fmt.Errorf("%q requires API version %s, but the Docker server is version %s", feature, APIrequired, version)
 	}
 	return nil
 }
 
+// newVersionError is like NewVersionError, but negotiates the API version
+// first if needed.
+func (cli *Client) newVersionError(ctx context.Context, APIrequired, feature string) error {
+	cli.apiVersion(ctx)
+	return cli.NewVersionError(APIrequired, feature)
+}
+
 // secretNotFoundError implements an error returned when a secret is not found.
 type secretNotFoundError struct {
 	name string
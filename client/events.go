@@ -26,7 +26,7 @@ func (cli *Client) Events(ctx context.Context, options types.EventsOptions) (<-c
 	go func() {
 		defer close(errs)
 
-		query, err := buildEventsQueryParams(cli.version, options)
+		query, err := buildEventsQueryParams(cli.apiVersion(ctx), options)
 		if err != nil {
 			close(started)
 			errs <- err
@@ -39,7 +39,7 @@ func (cli *Client) postHijacked(ctx context.Context, path string, query url.Valu
 		return types.HijackedResponse{}, err
 	}
 
-	apiPath := cli.getAPIPath(path, query)
+	apiPath := cli.getAPIPath(ctx, path, query)
 	req, err := http.NewRequest("POST", apiPath, bodyEncoded)
 	if err != nil {
 		return types.HijackedResponse{}, err
@@ -47,6 +47,9 @@ func (cli *Client) postHijacked(ctx context.Context, path string, query url.Valu
 	req = cli.addHeaders(req, headers)
 
 	req.Host = cli.addr
+	if cli.proto == "ssh" {
+		req.Host = "docker"
+	}
 	req.Header.Set("Connection", "Upgrade")
 	req.Header.Set("Upgrade", "tcp")
 
@@ -165,7 +168,18 @@ func tlsDialWithDialer(dialer *net.Dialer, network, addr string, config *tls.Con
 	return &tlsClientCon{conn, rawConn}, nil
 }
 
+// Dialer returns a function that opens a raw connection to the daemon, using
+// the same protocol, address and TLS configuration as hijacked requests.
+func (cli *Client) Dialer() func() (net.Conn, error) {
+	return func() (net.Conn, error) {
+		return dial(cli.proto, cli.addr, resolveTLSConfig(cli.client.Transport))
+	}
+}
+
 func dial(proto, addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	if proto == "ssh" {
+		return dialSSH(addr)
+	}
 	if tlsConfig != nil && proto != "unix" && proto != "npipe" {
 		// Notice this isn't Go standard's tls.Dial function
 		return tlsDial(proto, addr, tlsConfig)
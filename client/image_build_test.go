@@ -156,9 +156,21 @@ func TestImageBuild(t *testing.T) {
 			expectedTags:           []string{},
 			expectedRegistryConfig: "eyJodHRwczovL2luZGV4LmRvY2tlci5pby92MS8iOnsiYXV0aCI6ImRHOTBid289In19",
 		},
+		{
+			buildOptions: types.ImageBuildOptions{
+				Secrets: map[string]string{
+					"npmrc": ".secret.npmrc",
+				},
+			},
+			expectedQueryParams: map[string]string{
+				"secrets": `{"npmrc":".secret.npmrc"}`,
+			},
+			expectedTags:           []string{},
+			expectedRegistryConfig: emptyRegistryConfig,
+		},
 	}
 	for _, buildCase := range buildCases {
-		expectedURL := "/build"
+		expectedURL := "/v1.26/build"
 		client := &Client{
 			client: newMockClient(func(r *http.Request) (*http.Response, error) {
 				if !strings.HasPrefix(r.URL.Path, expectedURL) {
@@ -199,6 +211,7 @@ func TestImageBuild(t *testing.T) {
 					Header:     headers,
 				}, nil
 			}),
+			version: "1.26",
 		}
 		buildResponse, err := client.ImageBuild(context.Background(), nil, buildCase.buildOptions)
 		if err != nil {
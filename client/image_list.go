@@ -17,14 +17,14 @@ func (cli *Client) ImageList(ctx context.Context, options types.ImageListOptions
 
 	optionFilters := options.Filters
 	referenceFilters := optionFilters.Get("reference")
-	if versions.LessThan(cli.version, "1.25") && len(referenceFilters) > 0 {
+	if versions.LessThan(cli.apiVersion(ctx), "1.25") && len(referenceFilters) > 0 {
 		query.Set("filter", referenceFilters[0])
 		for _, filterValue := range referenceFilters {
 			optionFilters.Del("reference", filterValue)
 		}
 	}
 	if optionFilters.Len() > 0 {
-		filterJSON, err := filters.ToParamWithVersion(cli.version, optionFilters)
+		filterJSON, err := filters.ToParamWithVersion(cli.apiVersion(ctx), optionFilters)
 		if err != nil {
 			return images, err
 		}
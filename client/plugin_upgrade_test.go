@@ -0,0 +1,157 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestPluginUpgradeError(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.26",
+	}
+
+	_, err := client.PluginUpgrade(context.Background(), "plugin_name", types.PluginInstallOptions{RemoteRef: "plugin_name:v2"})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestPluginUpgradeVersionError(t *testing.T) {
+	client := &Client{version: "1.25"}
+
+	_, err := client.PluginUpgrade(context.Background(), "plugin_name", types.PluginInstallOptions{RemoteRef: "plugin_name:v2"})
+	if err == nil || err.Error() != `"plugin upgrade" requires API version 1.26, but the Docker server is version 1.25` {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
+
+// upgradeMock serves a plugin that requires installed, and a remote version
+// of it that requires remote.
+func upgradeMock(installed, remote types.PluginPrivileges, upgraded *types.PluginPrivileges) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		var body interface{}
+		switch strings.TrimPrefix(req.URL.Path, "/v1.26") {
+		case "/plugins/plugin_name/json":
+			var config types.PluginConfig
+			for _, p := range installed {
+				if p.Name == "network" {
+					config.Network.Type = p.Value[0]
+				}
+			}
+			body = types.Plugin{Name: "plugin_name:latest", Config: config}
+		case "/plugins/privileges":
+			if req.URL.Query().Get("remote") != "plugin_name:v2" {
+				return nil, fmt.Errorf("unexpected remote %q", req.URL.Query().Get("remote"))
+			}
+			body = remote
+		case "/plugins/plugin_name/upgrade":
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			if err := json.NewDecoder(req.Body).Decode(upgraded); err != nil {
+				return nil, err
+			}
+			body = map[string]string{"status": "upgraded"}
+		default:
+			return nil, fmt.Errorf("unexpected URL %q", req.URL)
+		}
+		content, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(content)),
+		}, nil
+	}
+}
+
+func TestPluginUpgradePrivileges(t *testing.T) {
+	host := types.PluginPrivileges{{
+		Name:        "network",
+		Description: "permissions to access a network",
+		Value:       []string{"host"},
+	}}
+
+	cases := []struct {
+		installed types.PluginPrivileges
+		remote    types.PluginPrivileges
+		accept    bool
+		asked     bool
+		denied    bool
+	}{
+		{installed: host, remote: host, asked: false},
+		{installed: nil, remote: nil, asked: false},
+		{installed: nil, remote: host, accept: true, asked: true},
+		{installed: nil, remote: host, accept: false, asked: true, denied: true},
+	}
+
+	for i, cs := range cases {
+		var upgraded types.PluginPrivileges
+		client := &Client{
+			client:  newMockClient(upgradeMock(cs.installed, cs.remote, &upgraded)),
+			version: "1.26",
+		}
+
+		asked := false
+		options := types.PluginInstallOptions{
+			RemoteRef: "plugin_name:v2",
+			AcceptPermissionsFunc: func(privileges types.PluginPrivileges) (bool, error) {
+				asked = true
+				return cs.accept, nil
+			},
+		}
+		rc, err := client.PluginUpgrade(context.Background(), "plugin_name", options)
+		if asked != cs.asked {
+			t.Fatalf("%d: expected asked to be %v", i, cs.asked)
+		}
+		if cs.denied {
+			if !IsErrPluginPermissionDenied(err) {
+				t.Fatalf("%d: expected a permission denied error, got %v", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		rc.Close()
+		if !reflect.DeepEqual(upgraded, cs.remote) {
+			t.Fatalf("%d: expected privileges %v to be sent, got %v", i, cs.remote, upgraded)
+		}
+	}
+}
+
+func TestPluginUpgradeForce(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		var sent string
+		mock := upgradeMock(nil, nil, new(types.PluginPrivileges))
+		client := &Client{
+			client: newMockClient(func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/upgrade") {
+					sent = req.URL.Query().Get("force")
+				}
+				return mock(req)
+			}),
+			version: "1.26",
+		}
+
+		rc, err := client.PluginUpgrade(context.Background(), "plugin_name", types.PluginInstallOptions{RemoteRef: "plugin_name:v2", Force: force})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc.Close()
+		if expected := map[bool]string{false: "", true: "1"}[force]; sent != expected {
+			t.Fatalf("expected force=%q to be sent, got %q", expected, sent)
+		}
+	}
+}
@@ -98,9 +98,11 @@ func (cli *Client) buildRequest(method, path string, body io.Reader, headers hea
 	}
 	req = cli.addHeaders(req, headers)
 
-	if cli.proto == "unix" || cli.proto == "npipe" {
-		// For local communications, it doesn't matter what the host is. We just
-		// need a valid and meaningful host name. (See #189)
+	if cli.proto == "unix" || cli.proto == "npipe" || cli.proto == "ssh" {
+		// For local communications, and for ssh where the connection is
+		// bridged to a local socket on the remote host, it doesn't matter
+		// what the host is. We just need a valid and meaningful host name.
+		// (See #189)
 		req.Host = "docker"
 	}
 
@@ -114,7 +116,7 @@ func (cli *Client) buildRequest(method, path string, body io.Reader, headers hea
 }
 
 func (cli *Client) sendRequest(ctx context.Context, method, path string, query url.Values, body io.Reader, headers headers) (serverResponse, error) {
-	req, err := cli.buildRequest(method, cli.getAPIPath(path, query), body, headers)
+	req, err := cli.buildRequest(method, cli.getAPIPath(ctx, path, query), body, headers)
 	if err != nil {
 		return serverResponse{}, err
 	}
@@ -191,7 +193,7 @@ func (cli *Client) doRequest(ctx context.Context, req *http.Request) (serverResp
 		}
 
 		var errorMessage string
-		if (cli.version == "" || versions.GreaterThan(cli.version, "1.23")) &&
+		if version := cli.ClientVersion(); (version == "" || versions.GreaterThan(version, "1.23")) &&
 			resp.Header.Get("Content-Type") == "application/json" {
 			var errorResponse types.ErrorResponse
 			if err := json.Unmarshal(body, &errorResponse); err != nil {
@@ -214,7 +216,7 @@ func (cli *Client) addHeaders(req *http.Request, headers headers) *http.Request
 	// Add CLI Config's HTTP Headers BEFORE we set the Docker headers
 	// then the user can't change OUR headers
 	for k, v := range cli.customHTTPHeaders {
-		if versions.LessThan(cli.version, "1.25") && k == "User-Agent" {
+		if versions.LessThan(cli.ClientVersion(), "1.25") && k == "User-Agent" {
 			continue
 		}
 		req.Header.Set(k, v)
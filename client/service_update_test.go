@@ -75,3 +75,22 @@ func TestServiceUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceUpdateRollback(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if rollback := req.URL.Query().Get("rollback"); rollback != "previous" {
+				return nil, fmt.Errorf("expected rollback=previous in URL query, got '%s'", rollback)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+			}, nil
+		}),
+	}
+
+	_, err := client.ServiceUpdate(context.Background(), "service_id", swarm.Version{}, swarm.ServiceSpec{}, types.ServiceUpdateOptions{Rollback: "previous"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
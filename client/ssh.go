@@ -0,0 +1,148 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sshCommand is the command that is run to connect to ssh:// hosts. It is a
+// variable so that tests can replace it with a stand-in for ssh.
+var sshCommand = "ssh"
+
+// sshArgs returns the arguments for the ssh command to reach the daemon on
+// the remote host addr, which is in the [user@]host[:port] form. The remote
+// docker CLI bridges the ssh session to the daemon with `docker system
+// dial-stdio`.
+func sshArgs(addr string) ([]string, error) {
+	u, err := url.Parse("ssh://" + addr)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" || u.Path != "" || u.RawQuery != "" {
+		return nil, fmt.Errorf("invalid ssh address, expected ssh://[user@]host[:port]: ssh://%s", addr)
+	}
+
+	var args []string
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	host := u.Host
+	if h, port, err := net.SplitHostPort(u.Host); err == nil {
+		host = h
+		args = append(args, "-p", port)
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return append(args, "--", host, "docker", "system", "dial-stdio"), nil
+}
+
+// dialSSH starts an ssh session to addr and returns a connection that reads
+// from and writes to the standard output and input of the session.
+func dialSSH(addr string) (net.Conn, error) {
+	args, err := sshArgs(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(sshCommand, args...)
+	c := &commandConn{cmd: cmd, addr: addr}
+	cmd.Stderr = &c.stderr
+	if c.stdin, err = cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+	if c.stdout, err = cmd.StdoutPipe(); err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh://%s: %v", addr, err)
+	}
+	return c, nil
+}
+
+// commandConn implements net.Conn on top of the standard input and output of
+// a command.
+type commandConn struct {
+	cmd    *exec.Cmd
+	addr   string
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+
+	waitOnce  sync.Once
+	waitErr   error
+	closeOnce sync.Once
+	closed    int32
+}
+
+// wait waits for the command to exit. The standard error of the command can
+// only be read once wait returned.
+func (c *commandConn) wait() error {
+	c.waitOnce.Do(func() {
+		c.waitErr = c.cmd.Wait()
+	})
+	return c.waitErr
+}
+
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF && atomic.LoadInt32(&c.closed) == 0 {
+		if werr := c.wait(); werr != nil {
+			return n, fmt.Errorf("ssh connection to ssh://%s failed: %v: %s", c.addr, werr, strings.TrimSpace(c.stderr.String()))
+		}
+	}
+	return n, err
+}
+
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// CloseWrite closes the standard input of the command, so that the remote
+// end sees the end of the stream.
+func (c *commandConn) CloseWrite() error {
+	return c.stdin.Close()
+}
+
+// Close closes the connection and stops the command.
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		atomic.StoreInt32(&c.closed, 1)
+		c.stdin.Close()
+		if c.cmd.Process != nil {
+			c.cmd.Process.Kill()
+		}
+		c.wait()
+	})
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr {
+	return sshAddr(c.addr)
+}
+
+func (c *commandConn) RemoteAddr() net.Addr {
+	return sshAddr(c.addr)
+}
+
+// Deadlines are not supported on the standard streams of a command.
+func (c *commandConn) SetDeadline(t time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// sshAddr is the net.Addr of an ssh connection.
+type sshAddr string
+
+func (a sshAddr) Network() string {
+	return "ssh"
+}
+
+func (a sshAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// sshStandInEnv is set in the environment of the test binary when it is run
+// as a stand-in for ssh. It holds the address of the daemon to connect to.
+const sshStandInEnv = "DOCKER_CLIENT_TEST_SSH_STAND_IN"
+
+func TestMain(m *testing.M) {
+	if addr := os.Getenv(sshStandInEnv); addr != "" {
+		if err := sshStandIn(addr, os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// sshStandIn behaves like `ssh host docker system dial-stdio` run against a
+// daemon listening on addr.
+func sshStandIn(addr string, args []string) error {
+	if len(args) < 4 || !reflect.DeepEqual(args[len(args)-3:], []string{"docker", "system", "dial-stdio"}) {
+		return fmt.Errorf("unexpected ssh arguments: %v", args)
+	}
+	if args[len(args)-4] == "unreachable" {
+		return fmt.Errorf("ssh: Could not resolve hostname unreachable")
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	go func() {
+		io.Copy(conn, os.Stdin)
+		conn.(*net.TCPConn).CloseWrite()
+	}()
+	_, err = io.Copy(os.Stdout, conn)
+	return err
+}
+
+// useSSHStandIn makes the client run the test binary instead of ssh, and
+// returns a function that restores the ssh command.
+func useSSHStandIn(addr string) func() {
+	os.Setenv(sshStandInEnv, addr)
+	sshCommand = os.Args[0]
+	return func() {
+		os.Unsetenv(sshStandInEnv)
+		sshCommand = "ssh"
+	}
+}
+
+func TestSSHArgs(t *testing.T) {
+	cases := []struct {
+		addr     string
+		expected []string
+	}{
+		{"host", []string{"--", "host", "docker", "system", "dial-stdio"}},
+		{"me@host", []string{"-l", "me", "--", "host", "docker", "system", "dial-stdio"}},
+		{"me@host:2222", []string{"-l", "me", "-p", "2222", "--", "host", "docker", "system", "dial-stdio"}},
+		{"[::1]:2222", []string{"-p", "2222", "--", "::1", "docker", "system", "dial-stdio"}},
+	}
+	for _, cs := range cases {
+		args, err := sshArgs(cs.addr)
+		if err != nil {
+			t.Fatalf("%s: %v", cs.addr, err)
+		}
+		if !reflect.DeepEqual(args, cs.expected) {
+			t.Fatalf("%s: expected %v, got %v", cs.addr, cs.expected, args)
+		}
+	}
+
+	for _, addr := range []string{"", "host/path", "host?query"} {
+		if _, err := sshArgs(addr); err == nil {
+			t.Fatalf("%q: expected an error", addr)
+		}
+	}
+}
+
+func TestSSHTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "docker" {
+			http.Error(w, "unexpected host "+r.Host, http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/_ping":
+			w.Header().Set("API-Version", DefaultVersion)
+			fmt.Fprint(w, "OK")
+		case "/v" + DefaultVersion + "/containers/echo/attach":
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			fmt.Fprintf(rw, "HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+			rw.Flush()
+			io.Copy(rw, rw)
+			rw.Flush()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	defer useSSHStandIn(ts.Listener.Addr().String())()
+
+	client, err := NewClient("ssh://me@remote:2222", DefaultVersion, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ping, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ping.APIVersion != DefaultVersion {
+		t.Fatalf("expected API version %s, got %s", DefaultVersion, ping.APIVersion)
+	}
+
+	resp, err := client.ContainerAttach(context.Background(), "echo", types.ContainerAttachOptions{Stream: true, Stdin: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Close()
+
+	if _, err := resp.Conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := resp.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(resp.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "hello" {
+		t.Fatalf("expected hello, got %q", out)
+	}
+}
+
+func TestSSHTransportError(t *testing.T) {
+	defer useSSHStandIn("127.0.0.1:0")()
+
+	client, err := NewClient("ssh://unreachable", DefaultVersion, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	_, err = client.Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve hostname unreachable") {
+		t.Fatalf("expected the ssh error, got %v", err)
+	}
+}
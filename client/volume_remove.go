@@ -10,7 +10,7 @@ import (
 // VolumeRemove removes a volume from the docker host.
 func (cli *Client) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
 	query := url.Values{}
-	if versions.GreaterThanOrEqualTo(cli.version, "1.25") {
+	if versions.GreaterThanOrEqualTo(cli.apiVersion(ctx), "1.25") {
 		if force {
 			query.Set("force", "1")
 		}
@@ -337,18 +337,24 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 func (cli *DaemonCli) reloadConfig() {
 	reload := func(config *daemon.Config) {
 
-		// Revalidate and reload the authorization plugins
-		if err := validateAuthzPlugins(config.AuthorizationPlugins, cli.d.PluginStore); err != nil {
-			logrus.Fatalf("Error validating authorization plugin: %v", err)
-			return
+		// Revalidate the authorization plugins before changing anything, so
+		// that an invalid plugin rejects the whole reload.
+		if config.IsValueSet("authorization-plugins") {
+			if err := validateAuthzPlugins(config.AuthorizationPlugins, cli.d.PluginStore); err != nil {
+				logrus.Errorf("Error validating authorization plugin: %v", err)
+				return
+			}
 		}
-		cli.authzMiddleware.SetPlugins(config.AuthorizationPlugins)
 
 		if err := cli.d.Reload(config); err != nil {
 			logrus.Errorf("Error reconfiguring the daemon: %v", err)
 			return
 		}
 
+		if config.IsValueSet("authorization-plugins") {
+			cli.authzMiddleware.SetPlugins(config.AuthorizationPlugins)
+		}
+
 		if config.IsValueSet("debug") {
 			debugEnabled := debug.IsEnabled()
 			switch {
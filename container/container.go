@@ -24,6 +24,7 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -38,6 +39,7 @@ import (
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/docker/volume"
 	"github.com/docker/go-connections/nat"
+	units "github.com/docker/go-units"
 	"github.com/docker/libnetwork"
 	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/options"
@@ -48,6 +50,10 @@ import (
 
 const configFileName = "config.v2.json"
 
+// logCacheFileName is the file of the local cache of the logs, kept for the
+// logging drivers that cannot read logs back.
+const logCacheFileName = "container-cached.log"
+
 const (
 	// DefaultStopTimeout is the timeout (in seconds) for the syscall signal used to stop a container.
 	DefaultStopTimeout = 10
@@ -349,7 +355,64 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 			return nil, err
 		}
 	}
-	return c(ctx)
+
+	l, err := c(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
+		var bufferSize int64
+		if s, exists := cfg.Config["max-buffer-size"]; exists {
+			bufferSize, err = units.RAMInBytes(s)
+			if err != nil {
+				l.Close()
+				return nil, err
+			}
+		}
+		l = logger.NewRingLogger(l, bufferSize)
+	}
+
+	// Keep a local copy of the logs of drivers that cannot read them back, so
+	// that "docker logs" works with any driver.
+	if _, ok := l.(logger.LogReader); !ok && cache.ShouldUseCache(cfg.Config) {
+		ctx.LogPath, err = container.GetRootResourcePath(logCacheFileName)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		cl, err := cache.WithLocalCache(l, ctx)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = cl
+	}
+	return l, nil
+}
+
+// OpenLogCache opens the local cache of the logs of the container for
+// reading, without starting its logging driver. It returns nil if the
+// container has no cache, because its logging driver reads logs back itself
+// or the cache is disabled.
+func (container *Container) OpenLogCache() (logger.Logger, error) {
+	cfg := container.HostConfig.LogConfig
+	if !cache.ShouldUseCache(cfg.Config) {
+		return nil, nil
+	}
+	logPath, err := container.GetRootResourcePath(logCacheFileName)
+	if err != nil {
+		return nil, err
+	}
+	l, err := cache.NewReader(logger.Context{
+		Config:      cfg.Config,
+		ContainerID: container.ID,
+		LogPath:     logPath,
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return l, err
 }
 
 // GetProcessLabel returns the process label for the container.
@@ -979,6 +1042,37 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 	return createOptions, nil
 }
 
+// mergeRestartPolicy returns the restart policy current updated with the
+// fields set in update, so that "docker update --restart", which only sets
+// the name and retry count of the policy, keeps the delays and crash loop
+// detection of the container. These do not apply without a restart policy,
+// so they are reset when the policy is changed to "no".
+func mergeRestartPolicy(current, update containertypes.RestartPolicy) (containertypes.RestartPolicy, error) {
+	merged := update
+	if update.IsNone() {
+		return merged, nil
+	}
+	if merged.InitialDelay == 0 {
+		merged.InitialDelay = current.InitialDelay
+	}
+	if merged.MaxDelay == 0 {
+		merged.MaxDelay = current.MaxDelay
+	}
+	if merged.ResetWindow == 0 {
+		merged.ResetWindow = current.ResetWindow
+	}
+	if merged.CrashLoopThreshold == 0 {
+		merged.CrashLoopThreshold = current.CrashLoopThreshold
+	}
+	if merged.CrashLoopWindow == 0 {
+		merged.CrashLoopWindow = current.CrashLoopWindow
+	}
+	if merged.MaxDelay != 0 && merged.MaxDelay < merged.InitialDelay {
+		return merged, fmt.Errorf("maximum restart delay cannot be less than the initial restart delay")
+	}
+	return merged, nil
+}
+
 // UpdateMonitor updates monitor configure for running container
 func (container *Container) UpdateMonitor(restartPolicy containertypes.RestartPolicy) {
 	type policySetter interface {
@@ -1003,6 +1097,15 @@ func (container *Container) FullHostname() string {
 func (container *Container) RestartManager() restartmanager.RestartManager {
 	if container.restartManager == nil {
 		container.restartManager = restartmanager.New(container.HostConfig.RestartPolicy, container.RestartCount)
+		if container.CrashLooping {
+			// keep the crash loop across daemon restarts
+			type crashLoopSetter interface {
+				SetCrashLooping()
+			}
+			if rm, ok := container.restartManager.(crashLoopSetter); ok {
+				rm.SetCrashLooping()
+			}
+		}
 	}
 	return container.restartManager
 }
@@ -2,6 +2,7 @@ package container
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/pkg/signal"
@@ -58,3 +59,43 @@ func TestContainerStopTimeout(t *testing.T) {
 		t.Fatalf("Expected 15, got %v", s)
 	}
 }
+
+func TestMergeRestartPolicy(t *testing.T) {
+	current := container.RestartPolicy{
+		Name:               "always",
+		InitialDelay:       time.Second,
+		MaxDelay:           time.Minute,
+		CrashLoopThreshold: 3,
+	}
+
+	merged, err := mergeRestartPolicy(current, container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := current
+	expected.Name = "on-failure"
+	expected.MaximumRetryCount = 5
+	if merged != expected {
+		t.Fatalf("expected the delays and crash loop detection to be kept, got %+v", merged)
+	}
+
+	merged, err = mergeRestartPolicy(current, container.RestartPolicy{Name: "always", MaxDelay: 2 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.MaxDelay != 2*time.Minute || merged.InitialDelay != time.Second {
+		t.Fatalf("expected only the maximum delay to be updated, got %+v", merged)
+	}
+
+	merged, err = mergeRestartPolicy(current, container.RestartPolicy{Name: "no"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged != (container.RestartPolicy{Name: "no"}) {
+		t.Fatalf("expected the delays and crash loop detection to be reset, got %+v", merged)
+	}
+
+	if _, err := mergeRestartPolicy(current, container.RestartPolicy{Name: "always", InitialDelay: 2 * time.Minute}); err == nil {
+		t.Fatal("expected an error for an initial delay greater than the maximum delay")
+	}
+}
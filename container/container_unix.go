@@ -323,13 +323,20 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.DeviceCgroupRules != nil {
+		cResources.DeviceCgroupRules = resources.DeviceCgroupRules
+	}
 
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
 			return fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container")
 		}
-		container.HostConfig.RestartPolicy = hostConfig.RestartPolicy
+		restartPolicy, err := mergeRestartPolicy(container.HostConfig.RestartPolicy, hostConfig.RestartPolicy)
+		if err != nil {
+			return err
+		}
+		container.HostConfig.RestartPolicy = restartPolicy
 	}
 
 	if err := container.ToDisk(); err != nil {
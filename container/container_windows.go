@@ -82,7 +82,11 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
 			return fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container")
 		}
-		container.HostConfig.RestartPolicy = hostConfig.RestartPolicy
+		restartPolicy, err := mergeRestartPolicy(container.HostConfig.RestartPolicy, hostConfig.RestartPolicy)
+		if err != nil {
+			return err
+		}
+		container.HostConfig.RestartPolicy = restartPolicy
 	}
 	return nil
 }
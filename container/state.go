@@ -1,6 +1,7 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/go-units"
 )
 
@@ -21,6 +23,7 @@ type State struct {
 	Running           bool
 	Paused            bool
 	Restarting        bool
+	CrashLooping      bool // restarted too often within the crash loop window of its restart policy
 	OOMKilled         bool
 	RemovalInProgress bool // Not need for this to be persistent on disk.
 	Dead              bool
@@ -30,6 +33,7 @@ type State struct {
 	StartedAt         time.Time
 	FinishedAt        time.Time
 	waitChan          chan struct{}
+	waitRemove        chan struct{}
 	Health            *Health
 }
 
@@ -59,10 +63,19 @@ func (ss *StateStatus) Error() string {
 	return ss.error
 }
 
+// Err returns current error for the state, or nil if there is none.
+func (ss *StateStatus) Err() error {
+	if ss.error == "" {
+		return nil
+	}
+	return errors.New(ss.error)
+}
+
 // NewState creates a default state object with a fresh channel for state changes.
 func NewState() *State {
 	return &State{
-		waitChan: make(chan struct{}),
+		waitChan:   make(chan struct{}),
+		waitRemove: make(chan struct{}),
 	}
 }
 
@@ -73,6 +86,9 @@ func (s *State) String() string {
 			return fmt.Sprintf("Up %s (Paused)", units.HumanDuration(time.Now().UTC().Sub(s.StartedAt)))
 		}
 		if s.Restarting {
+			if s.CrashLooping {
+				return fmt.Sprintf("Restarting (%d) %s ago (crash loop)", s.ExitCodeValue, units.HumanDuration(time.Now().UTC().Sub(s.FinishedAt)))
+			}
 			return fmt.Sprintf("Restarting (%d) %s ago", s.ExitCodeValue, units.HumanDuration(time.Now().UTC().Sub(s.FinishedAt)))
 		}
 
@@ -220,6 +236,53 @@ func (s *State) WaitWithContext(ctx context.Context) error {
 	}
 }
 
+// Wait waits until the container is in a certain state indicated by the given
+// condition. A status result is sent on the returned channel once the
+// condition is met, or with an exit code of -1 and the context's error if the
+// context is done first. The returned channel is buffered, so the result is
+// not lost if the caller stops receiving.
+//
+// The wait is registered before Wait returns, so a container that is started
+// after Wait is called is waited on even if it exits immediately.
+func (s *State) Wait(ctx context.Context, condition containertypes.WaitCondition) <-chan StateStatus {
+	s.Lock()
+	defer s.Unlock()
+
+	resultC := make(chan StateStatus, 1)
+
+	if condition == containertypes.WaitConditionNotRunning && !s.Running {
+		resultC <- *newStateStatus(s.ExitCode(), s.Error())
+		return resultC
+	}
+
+	// When waiting for removal only, waitStop stays nil and never fires.
+	var waitStop chan struct{}
+	if condition != containertypes.WaitConditionRemoved {
+		waitStop = s.waitChan
+	}
+
+	// Always wait for removal too, as a container that is removed while it
+	// is in the "created" state never stops.
+	waitRemove := s.waitRemove
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			resultC <- *newStateStatus(-1, ctx.Err().Error())
+			return
+		case <-waitStop:
+		case <-waitRemove:
+		}
+
+		s.Lock()
+		result := newStateStatus(s.ExitCode(), s.Error())
+		s.Unlock()
+		resultC <- *result
+	}()
+
+	return resultC
+}
+
 // IsRunning returns whether the running flag is set. Used by Container to check whether a container is running.
 func (s *State) IsRunning() bool {
 	s.Lock()
@@ -265,6 +328,7 @@ func (s *State) SetStopped(exitStatus *ExitStatus) {
 	s.Running = false
 	s.Paused = false
 	s.Restarting = false
+	s.CrashLooping = false
 	s.Pid = 0
 	s.FinishedAt = time.Now().UTC()
 	s.setFromExitStatus(exitStatus)
@@ -335,6 +399,25 @@ func (s *State) SetDead() {
 	s.Unlock()
 }
 
+// SetRemoved assumes this container is already in the "dead" state and
+// fires the waiters for removal.
+func (s *State) SetRemoved() {
+	s.Lock()
+	close(s.waitRemove) // fire waiters for removal
+	s.waitRemove = make(chan struct{})
+	s.Unlock()
+}
+
+// SetRemovalError records the error that occurred when removing the
+// container, and fires the waiters for removal with it.
+func (s *State) SetRemovalError(err error) {
+	s.Lock()
+	s.SetError(err)
+	close(s.waitRemove) // fire waiters for removal
+	s.waitRemove = make(chan struct{})
+	s.Unlock()
+}
+
 // Error returns current error for the state.
 func (s *State) Error() string {
 	return s.ErrorMsg
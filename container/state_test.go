@@ -1,11 +1,15 @@
 package container
 
 import (
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"golang.org/x/net/context"
+
 	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
 )
 
 func TestIsValidHealthString(t *testing.T) {
@@ -111,3 +115,96 @@ func TestStateTimeoutWait(t *testing.T) {
 	}
 
 }
+
+func TestStateWaitConditions(t *testing.T) {
+	s := NewState()
+
+	// A container that is not running satisfies "not-running" right away.
+	select {
+	case status := <-s.Wait(context.Background(), containertypes.WaitConditionNotRunning):
+		if status.ExitCode() != 0 || status.Err() != nil {
+			t.Fatalf("unexpected status: exit code %d, err %v", status.ExitCode(), status.Err())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("not-running wait did not return for a stopped container")
+	}
+
+	// "next-exit" ignores the current state and waits for the next exit,
+	// even when the container is started after the wait begins.
+	nextExitC := s.Wait(context.Background(), containertypes.WaitConditionNextExit)
+	removedC := s.Wait(context.Background(), containertypes.WaitConditionRemoved)
+
+	s.Lock()
+	s.SetRunning(100, true)
+	s.Unlock()
+
+	select {
+	case <-nextExitC:
+		t.Fatal("next-exit wait returned before the container exited")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.Lock()
+	s.SetStopped(&ExitStatus{ExitCode: 3})
+	s.Unlock()
+
+	select {
+	case status := <-nextExitC:
+		if status.ExitCode() != 3 {
+			t.Fatalf("ExitCode %v, expected 3", status.ExitCode())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("next-exit wait did not return after the container exited")
+	}
+
+	// "removed" only returns once the container is removed.
+	select {
+	case <-removedC:
+		t.Fatal("removed wait returned before the container was removed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.SetRemoved()
+
+	select {
+	case status := <-removedC:
+		if status.ExitCode() != 3 {
+			t.Fatalf("ExitCode %v, expected 3", status.ExitCode())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("removed wait did not return after the container was removed")
+	}
+
+	// A removal error is reported to the waiters.
+	removedC = s.Wait(context.Background(), containertypes.WaitConditionRemoved)
+	s.SetRemovalError(errors.New("device or resource busy"))
+
+	select {
+	case status := <-removedC:
+		if err := status.Err(); err == nil || err.Error() != "device or resource busy" {
+			t.Fatalf("expected the removal error, got %v", err)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("removed wait did not return after the removal failed")
+	}
+}
+
+func TestStateWaitCancel(t *testing.T) {
+	s := NewState()
+	s.Lock()
+	s.SetRunning(100, true)
+	s.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waitC := s.Wait(ctx, containertypes.WaitConditionNotRunning)
+	cancel()
+
+	select {
+	case status := <-waitC:
+		if status.ExitCode() != -1 || status.Err() == nil {
+			t.Fatalf("expected exit code -1 and an error, got %d and %v", status.ExitCode(), status.Err())
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("wait did not return after the context was cancelled")
+	}
+}
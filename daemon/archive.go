@@ -342,7 +342,7 @@ func (daemon *Daemon) containerCopy(container *container.Container, resource str
 // specified by a container object.
 // TODO: make sure callers don't unnecessarily convert destPath with filepath.FromSlash (Copy does it already).
 // CopyOnBuild should take in abstract paths (with slashes) and the implementation should convert it to OS-specific paths.
-func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool) error {
+func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool, chown string) error {
 	srcPath := src.Path()
 	destExists := true
 	destDir := false
@@ -361,6 +361,15 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 	}
 	defer daemon.Unmount(c)
 
+	// files are owned by the (remapped) root user, unless another owner
+	// was requested with --chown
+	uid, gid := rootUID, rootGID
+	if chown != "" {
+		if uid, gid, err = daemon.getChownIDs(c, chown); err != nil {
+			return err
+		}
+	}
+
 	dest, err := c.GetResourcePath(destPath)
 	if err != nil {
 		return err
@@ -396,7 +405,7 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		if err := archiver.CopyWithTar(srcPath, destPath); err != nil {
 			return err
 		}
-		return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+		return fixPermissions(srcPath, destPath, uid, gid, destExists)
 	}
 	if decompress && archive.IsArchivePath(srcPath) {
 		// Only try to untar if it is a file and that we've been told to decompress (when ADD-ing a remote file)
@@ -425,12 +434,12 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		destPath = filepath.Join(destPath, src.Name())
 	}
 
-	if err := idtools.MkdirAllNewAs(filepath.Dir(destPath), 0755, rootUID, rootGID); err != nil {
+	if err := idtools.MkdirAllNewAs(filepath.Dir(destPath), 0755, uid, gid); err != nil {
 		return err
 	}
 	if err := archiver.CopyFileWithTar(srcPath, destPath); err != nil {
 		return err
 	}
 
-	return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+	return fixPermissions(srcPath, destPath, uid, gid, destExists)
 }
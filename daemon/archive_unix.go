@@ -3,10 +3,14 @@
 package daemon
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/opencontainers/runc/libcontainer/user"
 )
 
 // checkIfPathIsInAVolume checks if the path is in a volume. If it is, it
@@ -56,3 +60,39 @@ func fixPermissions(source, destination string, uid, gid int, destExisted bool)
 		return os.Lchown(fullpath, uid, gid)
 	})
 }
+
+// getChownIDs resolves a "user[:group]" string of names or numeric ids
+// against the /etc/passwd and /etc/group files of the container, and returns
+// the matching ids on the host, taking user namespace remapping into account.
+func (daemon *Daemon) getChownIDs(c *container.Container, chown string) (int, int, error) {
+	passwdPath, err := user.GetPasswdPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	groupPath, err := user.GetGroupPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	if passwdPath, err = symlink.FollowSymlinkInScope(filepath.Join(c.BaseFS, passwdPath), c.BaseFS); err != nil {
+		return 0, 0, err
+	}
+	if groupPath, err = symlink.FollowSymlinkInScope(filepath.Join(c.BaseFS, groupPath), c.BaseFS); err != nil {
+		return 0, 0, err
+	}
+
+	execUser, err := user.GetExecUserPath(chown, nil, passwdPath, groupPath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to resolve --chown=%s: %v", chown, err)
+	}
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	uid, err := idtools.ToHost(execUser.Uid, uidMaps)
+	if err != nil {
+		return 0, 0, err
+	}
+	gid, err := idtools.ToHost(execUser.Gid, gidMaps)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uid, gid, nil
+}
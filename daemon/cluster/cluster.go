@@ -957,7 +957,7 @@ func (c *Cluster) GetService(input string) (types.Service, error) {
 }
 
 // UpdateService updates existing service to match new properties.
-func (c *Cluster) UpdateService(serviceIDOrName string, version uint64, spec types.ServiceSpec, encodedAuth string, registryAuthFrom string) (*apitypes.ServiceUpdateResponse, error) {
+func (c *Cluster) UpdateService(serviceIDOrName string, version uint64, spec types.ServiceSpec, flags apitypes.ServiceUpdateOptions) (*apitypes.ServiceUpdateResponse, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -969,19 +969,35 @@ func (c *Cluster) UpdateService(serviceIDOrName string, version uint64, spec typ
 	ctx, cancel := c.getRequestContext()
 	defer cancel()
 
-	err := c.populateNetworkID(ctx, state.controlClient, &spec)
+	currentService, err := getService(ctx, state.controlClient, serviceIDOrName)
 	if err != nil {
 		return nil, err
 	}
 
-	serviceSpec, err := convert.ServiceSpecToGRPC(spec)
-	if err != nil {
-		return nil, apierrors.NewBadRequestError(err)
-	}
+	encodedAuth := flags.EncodedRegistryAuth
+	registryAuthFrom := flags.RegistryAuthFrom
 
-	currentService, err := getService(ctx, state.controlClient, serviceIDOrName)
-	if err != nil {
-		return nil, err
+	var serviceSpec swarmapi.ServiceSpec
+	switch flags.Rollback {
+	case "", "none":
+		if err := c.populateNetworkID(ctx, state.controlClient, &spec); err != nil {
+			return nil, err
+		}
+
+		serviceSpec, err = convert.ServiceSpecToGRPC(spec)
+		if err != nil {
+			return nil, apierrors.NewBadRequestError(err)
+		}
+	case "previous":
+		if currentService.PreviousSpec == nil {
+			return nil, fmt.Errorf("service does not have a previous spec")
+		}
+		serviceSpec = *currentService.PreviousSpec.Copy()
+		if encodedAuth == "" {
+			registryAuthFrom = apitypes.RegistryAuthFromPreviousSpec
+		}
+	default:
+		return nil, apierrors.NewBadRequestError(fmt.Errorf("unrecognized rollback option %s", flags.Rollback))
 	}
 
 	newCtnr := serviceSpec.Task.GetContainer()
@@ -208,6 +208,10 @@ func containerToGRPC(c types.ContainerSpec) (*swarmapi.ContainerSpec, error) {
 	}
 
 	if c.Healthcheck != nil {
+		// swarmkit has no start period for health checks yet
+		if c.Healthcheck.StartPeriod != 0 {
+			return nil, fmt.Errorf("StartPeriod in Healthcheck is not supported for services")
+		}
 		containerSpec.Healthcheck = healthConfigToGRPC(c.Healthcheck)
 	}
 
@@ -36,6 +36,7 @@ func TaskFromGRPC(t swarmapi.Task) types.Task {
 			Placement:     placementFromGRPC(t.Spec.Placement),
 			LogDriver:     driverFromGRPC(t.Spec.LogDriver),
 			Networks:      networks,
+			ForceUpdate:   t.Spec.ForceUpdate,
 		},
 		Status: types.TaskStatus{
 			State:   types.TaskState(strings.ToLower(t.Status.State.String())),
@@ -10,11 +10,14 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/discovery"
 	"github.com/docker/docker/registry"
+	units "github.com/docker/go-units"
 	"github.com/imdario/mergo"
 	"github.com/spf13/pflag"
 )
@@ -42,15 +45,25 @@ const (
 	defaultShutdownTimeout = 15
 )
 
+const (
+	// defaultEventsLogMaxSize is the default maximum size of the events
+	// journal on disk.
+	defaultEventsLogMaxSize = "20m"
+	// defaultEventsLogMaxAge is the default maximum age of the events
+	// kept in the events journal.
+	defaultEventsLogMaxAge = "168h"
+)
+
 // flatOptions contains configuration keys
 // that MUST NOT be parsed as deep structures.
 // Use this to differentiate these options
 // with others like the ones in CommonTLSOptions.
 var flatOptions = map[string]bool{
-	"cluster-store-opts": true,
-	"log-opts":           true,
-	"runtimes":           true,
-	"default-ulimits":    true,
+	"cluster-store-opts":    true,
+	"log-opts":              true,
+	"runtimes":              true,
+	"default-ulimits":       true,
+	"registry-host-mirrors": true,
 }
 
 // LogConfig represents the default log configuration.
@@ -61,6 +74,14 @@ type LogConfig struct {
 	Config map[string]string `json:"log-opts,omitempty"`
 }
 
+// EventsLogConfig represents the retention of the events journal.
+// It includes json tags to deserialize configuration from a file
+// using the same names that the flags in the command line use.
+type EventsLogConfig struct {
+	MaxSize string `json:"events-log-max-size,omitempty"`
+	MaxAge  string `json:"events-log-max-age,omitempty"`
+}
+
 // commonBridgeConfig stores all the platform-common bridge driver specific
 // configuration.
 type commonBridgeConfig struct {
@@ -150,6 +171,7 @@ type CommonConfig struct {
 	MetricsAddress            string `json:"metrics-addr"`
 
 	LogConfig
+	EventsLogConfig
 	bridgeConfig // bridgeConfig holds bridge network specific configuration.
 	registry.ServiceOptions
 
@@ -182,6 +204,8 @@ func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
 	flags.Var(opts.NewNamedListOptsRef("labels", &config.Labels, opts.ValidateLabel), "label", "Set key=value labels to the daemon")
 	flags.StringVar(&config.LogConfig.Type, "log-driver", "json-file", "Default driver for container logs")
 	flags.Var(opts.NewNamedMapOpts("log-opts", config.LogConfig.Config, nil), "log-opt", "Default log driver options for containers")
+	flags.StringVar(&config.EventsLogConfig.MaxSize, "events-log-max-size", defaultEventsLogMaxSize, "Maximum size of the events log, 0 disables it")
+	flags.StringVar(&config.EventsLogConfig.MaxAge, "events-log-max-age", defaultEventsLogMaxAge, "Maximum age of the events kept in the events log, 0 keeps them until the log is full")
 	flags.StringVar(&config.ClusterAdvertise, "cluster-advertise", "", "Address or interface name to advertise")
 	flags.StringVar(&config.ClusterStore, "cluster-store", "", "URL of the distributed storage backend")
 	flags.Var(opts.NewNamedMapOpts("cluster-store-opts", config.ClusterOpts, nil), "cluster-store-opt", "Set cluster store options")
@@ -521,5 +545,37 @@ func ValidateConfiguration(config *Config) error {
 		}
 	}
 
+	if _, err := config.EventsLogConfig.journalConfig(); err != nil {
+		return err
+	}
+
+	// validate the mirrors of registries
+	if err := registry.ValidateHostMirrors(config.HostMirrors); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// journalConfig parses the retention settings of the events journal.
+func (c EventsLogConfig) journalConfig() (events.JournalConfig, error) {
+	var jc events.JournalConfig
+	if c.MaxSize != "" {
+		size, err := units.FromHumanSize(c.MaxSize)
+		if err != nil {
+			return jc, fmt.Errorf("invalid events log max size %q: %v", c.MaxSize, err)
+		}
+		jc.MaxSize = size
+	}
+	if c.MaxAge != "" && c.MaxAge != "0" {
+		age, err := time.ParseDuration(c.MaxAge)
+		if err != nil {
+			return jc, fmt.Errorf("invalid events log max age %q: %v", c.MaxAge, err)
+		}
+		if age < 0 {
+			return jc, fmt.Errorf("invalid events log max age %q: must not be negative", c.MaxAge)
+		}
+		jc.MaxAge = age
+	}
+	return jc, nil
+}
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/testutil/assert"
@@ -125,6 +126,36 @@ func TestDaemonConfigurationMergeConflictsWithInnerStructs(t *testing.T) {
 	}
 }
 
+func TestDaemonConfigurationRegistryHostMirrors(t *testing.T) {
+	f, err := ioutil.TempFile("", "docker-config-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	configFile := f.Name()
+	f.Write([]byte(`{"registry-host-mirrors": {"quay.io": [{"url": "https://mirror-1.com"}, {"url": "http://mirror-2.com", "insecure": true}]}}`))
+	f.Close()
+
+	c := &Config{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	c.ServiceOptions.InstallCliFlags(flags)
+
+	cc, err := MergeDaemonConfigurations(c, flags, configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirrors := cc.HostMirrors["quay.io"]
+	if len(mirrors) != 2 || mirrors[0].URL != "https://mirror-1.com" || mirrors[1].URL != "http://mirror-2.com" || !mirrors[1].Insecure {
+		t.Fatalf("unexpected mirrors of quay.io: %v", mirrors)
+	}
+
+	flags.Set("registry-host-mirror", "quay.io=https://mirror-3.com")
+	if _, err := MergeDaemonConfigurations(c, flags, configFile); err == nil || !strings.Contains(err.Error(), "registry-host-mirror") {
+		t.Fatalf("expected registry-host-mirror conflict, got %v", err)
+	}
+}
+
 func TestFindConfigurationConflictsWithUnknownKeys(t *testing.T) {
 	config := map[string]interface{}{"tls-verify": "true"}
 	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
@@ -227,3 +258,27 @@ func TestValidateConfiguration(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestEventsLogJournalConfig(t *testing.T) {
+	jc, err := EventsLogConfig{MaxSize: "10m", MaxAge: "24h"}.journalConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jc.MaxSize != 10*1000*1000 || jc.MaxAge != 24*time.Hour {
+		t.Fatalf("unexpected journal config: %+v", jc)
+	}
+
+	jc, err = EventsLogConfig{MaxSize: "0", MaxAge: "0"}.journalConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jc.MaxSize != 0 || jc.MaxAge != 0 {
+		t.Fatalf("expected the journal to be disabled, got %+v", jc)
+	}
+
+	for _, c := range []EventsLogConfig{{MaxSize: "ten"}, {MaxAge: "1 week"}, {MaxAge: "-1h"}} {
+		if _, err := c.journalConfig(); err == nil {
+			t.Fatalf("expected error for %+v", c)
+		}
+	}
+}
@@ -231,6 +231,10 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 			}
 		}
 
+		if err := validateHealthcheck(config.Healthcheck); err != nil {
+			return nil, err
+		}
+
 		// Validate if Env contains empty variable or not (e.g., ``, `=foo`)
 		for _, env := range config.Env {
 			if _, err := opts.ValidateEnv(env); err != nil {
@@ -277,6 +281,19 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 		return nil, fmt.Errorf("invalid restart policy '%s'", p.Name)
 	}
 
+	if p.InitialDelay < 0 || p.MaxDelay < 0 || p.ResetWindow < 0 || p.CrashLoopWindow < 0 {
+		return nil, fmt.Errorf("restart delays and windows cannot be negative")
+	}
+	if p.CrashLoopThreshold < 0 {
+		return nil, fmt.Errorf("crash loop threshold cannot be negative")
+	}
+	if p.MaxDelay != 0 && p.MaxDelay < p.InitialDelay {
+		return nil, fmt.Errorf("maximum restart delay cannot be less than the initial restart delay")
+	}
+	if p.IsNone() && (p.InitialDelay != 0 || p.MaxDelay != 0 || p.ResetWindow != 0 || p.CrashLoopThreshold != 0 || p.CrashLoopWindow != 0) {
+		return nil, fmt.Errorf("restart delays and crash loop detection cannot be used without a restart policy")
+	}
+
 	// Now do platform-specific verification
 	return verifyPlatformContainerSettings(daemon, hostConfig, config, update)
 }
@@ -14,6 +14,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"github.com/docker/docker/daemon/events"
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/initlayer"
+	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/plugin"
 	"github.com/docker/libnetwork/cluster"
@@ -566,6 +568,7 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't create plugin manager")
 	}
+	logger.RegisterPluginGetter(d.PluginStore)
 
 	d.layerStore, err = layer.NewStoreFromOptions(layer.StoreOptions{
 		StorePath:                 config.Root,
@@ -627,6 +630,17 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 	}
 
 	eventsService := events.New()
+	journalConfig, err := config.EventsLogConfig.journalConfig()
+	if err != nil {
+		return nil, err
+	}
+	if journalConfig.MaxSize > 0 {
+		journal, err := events.NewJournal(filepath.Join(config.Root, "events"), journalConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't open the events journal: %v", err)
+		}
+		eventsService.SetJournal(journal)
+	}
 
 	referenceStore, err := reference.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
 	if err != nil {
@@ -779,6 +793,15 @@ func (daemon *Daemon) ShutdownTimeout() int {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	// Close the events journal last, so that the events of the containers
+	// stopped below are written to it.
+	if daemon.EventsService != nil {
+		defer func() {
+			if err := daemon.EventsService.Close(); err != nil {
+				logrus.Errorf("Error closing the events journal: %v", err)
+			}
+		}()
+	}
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -990,17 +1013,28 @@ func (daemon *Daemon) initDiscovery(config *Config) error {
 // - Daemon labels.
 // - Daemon debug log level.
 // - Daemon insecure registries.
+// - Daemon registry mirrors.
+// - Daemon mirrors of registries other than the official one.
 // - Daemon max concurrent downloads
 // - Daemon max concurrent uploads
 // - Cluster discovery (reconfigure and restart).
 // - Daemon live restore
 // - Daemon shutdown timeout (in seconds).
+// - Default log driver and log options.
+// - Authorization plugins.
+// - Default DNS servers.
+// - Platform specific settings, see platformReload.
+//
+// All the settings are validated before any of them is changed, so that an
+// invalid configuration is rejected as a whole. The registry, live restore
+// and cluster discovery settings can still fail to apply, so they are applied
+// first, and the ones already applied are rolled back if one of them fails.
+// A reload is thus applied as a whole or not at all.
 func (daemon *Daemon) Reload(config *Config) (err error) {
+	var attributes map[string]string
 
 	daemon.configStore.reloadLock.Lock()
 
-	attributes := daemon.platformReload(config)
-
 	defer func() {
 		// we're unlocking here, because
 		// LogDaemonEventWithAttributes() -> SystemInfo() -> GetAllRuntimes()
@@ -1011,28 +1045,90 @@ func (daemon *Daemon) Reload(config *Config) (err error) {
 		}
 	}()
 
-	if err := daemon.reloadClusterDiscovery(config); err != nil {
+	logConfig, err := daemon.reloadValidate(config)
+	if err != nil {
 		return err
 	}
 
-	if config.IsValueSet("labels") {
-		daemon.configStore.Labels = config.Labels
-	}
-	if config.IsValueSet("debug") {
-		daemon.configStore.Debug = config.Debug
+	previous, err := daemon.reloadAttributes()
+	if err != nil {
+		return err
 	}
+
+	// The settings that can fail to apply are applied first. If one of them
+	// fails, the ones applied before are rolled back to the values still
+	// held by configStore, which is only updated once they all succeeded.
+	var rollback []func() error
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if rerr := rollback[i](); rerr != nil {
+				logrus.Errorf("Failed to roll back the daemon configuration after a failed reload: %v", rerr)
+			}
+		}
+	}()
+
 	if config.IsValueSet("insecure-registries") {
-		daemon.configStore.InsecureRegistries = config.InsecureRegistries
+		rollback = append(rollback, func() error {
+			return daemon.RegistryService.LoadInsecureRegistries(daemon.configStore.InsecureRegistries)
+		})
 		if err := daemon.RegistryService.LoadInsecureRegistries(config.InsecureRegistries); err != nil {
 			return err
 		}
 	}
+	if config.IsValueSet("registry-mirrors") {
+		rollback = append(rollback, func() error {
+			return daemon.RegistryService.LoadMirrors(daemon.configStore.Mirrors)
+		})
+		if err := daemon.RegistryService.LoadMirrors(config.Mirrors); err != nil {
+			return err
+		}
+	}
+	if config.IsValueSet("registry-host-mirrors") {
+		rollback = append(rollback, func() error {
+			return daemon.RegistryService.LoadHostMirrors(daemon.configStore.HostMirrors)
+		})
+		if err := daemon.RegistryService.LoadHostMirrors(config.HostMirrors); err != nil {
+			return err
+		}
+	}
 	if config.IsValueSet("live-restore") {
-		daemon.configStore.LiveRestoreEnabled = config.LiveRestoreEnabled
+		rollback = append(rollback, func() error {
+			return daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(daemon.configStore.LiveRestoreEnabled))
+		})
 		if err := daemon.containerdRemote.UpdateOptions(libcontainerd.WithLiveRestore(config.LiveRestoreEnabled)); err != nil {
 			return err
 		}
 	}
+	// cluster discovery comes last, it doesn't change the daemon if it fails
+	if err := daemon.reloadClusterDiscovery(config); err != nil {
+		return err
+	}
+	rollback = nil
+
+	if config.IsValueSet("insecure-registries") {
+		daemon.configStore.InsecureRegistries = config.InsecureRegistries
+	}
+	if config.IsValueSet("registry-mirrors") {
+		daemon.configStore.Mirrors = config.Mirrors
+	}
+	if config.IsValueSet("registry-host-mirrors") {
+		daemon.configStore.HostMirrors = config.HostMirrors
+	}
+	if config.IsValueSet("live-restore") {
+		daemon.configStore.LiveRestoreEnabled = config.LiveRestoreEnabled
+	}
+
+	daemon.platformReload(config)
+
+	if config.IsValueSet("labels") {
+		daemon.configStore.Labels = config.Labels
+	}
+	if config.IsValueSet("debug") {
+		daemon.configStore.Debug = config.Debug
+	}
 
 	// If no value is set for max-concurrent-downloads we assume it is the default value
 	// We always "reset" as the cost is lightweight and easy to maintain.
@@ -1065,25 +1161,143 @@ func (daemon *Daemon) Reload(config *Config) (err error) {
 		logrus.Debugf("Reset Shutdown Timeout: %d", daemon.configStore.ShutdownTimeout)
 	}
 
+	if logConfig != nil {
+		// defaultLogConfig is read under reloadLock, with getDefaultLogConfig
+		daemon.configStore.LogConfig = *logConfig
+		daemon.defaultLogConfig = containertypes.LogConfig{
+			Type:   logConfig.Type,
+			Config: logConfig.Config,
+		}
+		logrus.Debugf("Reset default logging driver: %s", daemon.defaultLogConfig.Type)
+	}
+
+	if config.IsValueSet("authorization-plugins") {
+		daemon.configStore.AuthorizationPlugins = config.AuthorizationPlugins
+	}
+
+	if config.IsValueSet("dns") {
+		daemon.configStore.DNS = config.DNS
+	}
+
 	// We emit daemon reload event here with updatable configurations
+	attributes, err = daemon.reloadAttributes()
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+	for key, value := range attributes {
+		if previous[key] != value {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	attributes["changed-keys"] = strings.Join(changed, ",")
+
+	return nil
+}
+
+// reloadValidate validates the settings of config that Reload changes,
+// without modifying the daemon. It returns the new default log
+// configuration, or nil if it does not change.
+func (daemon *Daemon) reloadValidate(config *Config) (*LogConfig, error) {
+	if config.IsValueSet("insecure-registries") {
+		for _, r := range config.InsecureRegistries {
+			if _, err := registry.ValidateIndexName(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.IsValueSet("registry-mirrors") {
+		for _, mirror := range config.Mirrors {
+			if _, err := registry.ValidateMirror(mirror); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.IsValueSet("registry-host-mirrors") {
+		if err := registry.ValidateHostMirrors(config.HostMirrors); err != nil {
+			return nil, err
+		}
+	}
+
+	var logConfig *LogConfig
+	if config.IsValueSet("log-driver") || config.IsValueSet("log-opts") {
+		logConfig = &LogConfig{
+			Type:   daemon.configStore.LogConfig.Type,
+			Config: daemon.configStore.LogConfig.Config,
+		}
+		if config.IsValueSet("log-driver") && config.LogConfig.Type != logConfig.Type {
+			// Options of the previous driver don't apply to the new one.
+			logConfig.Type = config.LogConfig.Type
+			logConfig.Config = map[string]string{}
+		}
+		if config.IsValueSet("log-opts") {
+			logConfig.Config = config.LogConfig.Config
+		}
+		if logConfig.Config == nil {
+			logConfig.Config = map[string]string{}
+		}
+		if err := logger.ValidateLogOpts(logConfig.Type, logConfig.Config); err != nil {
+			return nil, fmt.Errorf("failed to set log opts: %v", err)
+		}
+	}
+
+	if err := daemon.platformReloadValidate(config); err != nil {
+		return nil, err
+	}
+
+	return logConfig, nil
+}
+
+// reloadAttributes returns the current values of the settings that Reload
+// changes, as attributes of the daemon reload event.
+func (daemon *Daemon) reloadAttributes() (map[string]string, error) {
+	attributes, err := daemon.platformReloadAttributes()
+	if err != nil {
+		return nil, err
+	}
+
 	attributes["debug"] = fmt.Sprintf("%t", daemon.configStore.Debug)
 	attributes["live-restore"] = fmt.Sprintf("%t", daemon.configStore.LiveRestoreEnabled)
 
 	if daemon.configStore.InsecureRegistries != nil {
 		insecureRegistries, err := json.Marshal(daemon.configStore.InsecureRegistries)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		attributes["insecure-registries"] = string(insecureRegistries)
 	} else {
 		attributes["insecure-registries"] = "[]"
 	}
 
+	if daemon.configStore.Mirrors != nil {
+		mirrors, err := json.Marshal(daemon.configStore.Mirrors)
+		if err != nil {
+			return nil, err
+		}
+		attributes["registry-mirrors"] = string(mirrors)
+	} else {
+		attributes["registry-mirrors"] = "[]"
+	}
+
+	if daemon.configStore.HostMirrors != nil {
+		hostMirrors, err := json.Marshal(daemon.configStore.HostMirrors)
+		if err != nil {
+			return nil, err
+		}
+		attributes["registry-host-mirrors"] = string(hostMirrors)
+	} else {
+		attributes["registry-host-mirrors"] = "{}"
+	}
+
 	attributes["cluster-store"] = daemon.configStore.ClusterStore
 	if daemon.configStore.ClusterOpts != nil {
 		opts, err := json.Marshal(daemon.configStore.ClusterOpts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		attributes["cluster-store-opts"] = string(opts)
 	} else {
@@ -1094,18 +1308,53 @@ func (daemon *Daemon) Reload(config *Config) (err error) {
 	if daemon.configStore.Labels != nil {
 		labels, err := json.Marshal(daemon.configStore.Labels)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		attributes["labels"] = string(labels)
 	} else {
 		attributes["labels"] = "[]"
 	}
 
-	attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
-	attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	attributes["log-driver"] = daemon.configStore.LogConfig.Type
+	if daemon.configStore.LogConfig.Config != nil {
+		opts, err := json.Marshal(daemon.configStore.LogConfig.Config)
+		if err != nil {
+			return nil, err
+		}
+		attributes["log-opts"] = string(opts)
+	} else {
+		attributes["log-opts"] = "{}"
+	}
+
+	if daemon.configStore.AuthorizationPlugins != nil {
+		plugins, err := json.Marshal(daemon.configStore.AuthorizationPlugins)
+		if err != nil {
+			return nil, err
+		}
+		attributes["authorization-plugins"] = string(plugins)
+	} else {
+		attributes["authorization-plugins"] = "[]"
+	}
+
+	if daemon.configStore.DNS != nil {
+		dns, err := json.Marshal(daemon.configStore.DNS)
+		if err != nil {
+			return nil, err
+		}
+		attributes["dns"] = string(dns)
+	} else {
+		attributes["dns"] = "[]"
+	}
+
+	if daemon.configStore.MaxConcurrentDownloads != nil {
+		attributes["max-concurrent-downloads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentDownloads)
+	}
+	if daemon.configStore.MaxConcurrentUploads != nil {
+		attributes["max-concurrent-uploads"] = fmt.Sprintf("%d", *daemon.configStore.MaxConcurrentUploads)
+	}
 	attributes["shutdown-timeout"] = fmt.Sprintf("%d", daemon.configStore.ShutdownTimeout)
 
-	return nil
+	return attributes, nil
 }
 
 func (daemon *Daemon) reloadClusterDiscovery(config *Config) error {
@@ -459,6 +459,163 @@ func TestDaemonReloadNotAffectOthers(t *testing.T) {
 	}
 }
 
+func TestDaemonReloadMirrors(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.RegistryService = registry.NewService(registry.ServiceOptions{
+		Mirrors: []string{"https://mirror-1.com/"},
+	})
+	daemon.configStore = &Config{}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["registry-mirrors"] = []string{"https://mirror-2.com"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			ServiceOptions: registry.ServiceOptions{
+				Mirrors: []string{"https://mirror-2.com"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	mirrors := daemon.RegistryService.ServiceConfig().Mirrors
+	if len(mirrors) != 1 || mirrors[0] != "https://mirror-2.com/" {
+		t.Fatalf("Expected registry mirrors [https://mirror-2.com/], got %v", mirrors)
+	}
+}
+
+func TestDaemonReloadLogConfig(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			LogConfig: LogConfig{
+				Type:   "json-file",
+				Config: map[string]string{"max-size": "10m"},
+			},
+		},
+	}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["log-opts"] = map[string]string{"max-size": "20m"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			LogConfig: LogConfig{
+				Config: map[string]string{"max-size": "20m"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	if daemon.defaultLogConfig.Type != "json-file" {
+		t.Fatalf("Expected default log driver json-file, got %s", daemon.defaultLogConfig.Type)
+	}
+	if daemon.defaultLogConfig.Config["max-size"] != "20m" {
+		t.Fatalf("Expected default log opt max-size=20m, got %v", daemon.defaultLogConfig.Config)
+	}
+}
+
+func TestDaemonReloadInvalidConfigIsAtomic(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.RegistryService = registry.NewService(registry.ServiceOptions{})
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			Labels: []string{"foo:bar"},
+			DNS:    []string{"1.1.1.1"},
+			LogConfig: LogConfig{
+				Type:   "json-file",
+				Config: map[string]string{},
+			},
+		},
+	}
+
+	valuesSets := make(map[string]interface{})
+	valuesSets["labels"] = "foo:baz"
+	valuesSets["dns"] = []string{"2.2.2.2"}
+	valuesSets["registry-mirrors"] = []string{"https://mirror-1.com"}
+	valuesSets["log-opts"] = map[string]string{"no-such-opt": "foo"}
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			Labels: []string{"foo:baz"},
+			DNS:    []string{"2.2.2.2"},
+			ServiceOptions: registry.ServiceOptions{
+				Mirrors: []string{"https://mirror-1.com"},
+			},
+			LogConfig: LogConfig{
+				Config: map[string]string{"no-such-opt": "foo"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err == nil {
+		t.Fatal("Expected an error for an invalid log option")
+	}
+
+	if label := daemon.configStore.Labels[0]; label != "foo:bar" {
+		t.Fatalf("Expected daemon label `foo:bar`, got %s", label)
+	}
+	if dns := daemon.configStore.DNS[0]; dns != "1.1.1.1" {
+		t.Fatalf("Expected daemon dns 1.1.1.1, got %s", dns)
+	}
+	if mirrors := daemon.RegistryService.ServiceConfig().Mirrors; len(mirrors) != 0 {
+		t.Fatalf("Expected no registry mirrors, got %v", mirrors)
+	}
+}
+
+func TestDaemonReloadRollsBackOnFailure(t *testing.T) {
+	daemon := &Daemon{}
+	daemon.RegistryService = registry.NewService(registry.ServiceOptions{
+		Mirrors: []string{"https://mirror-1.com"},
+	})
+	daemon.configStore = &Config{
+		CommonConfig: CommonConfig{
+			Labels: []string{"foo:bar"},
+			ServiceOptions: registry.ServiceOptions{
+				Mirrors: []string{"https://mirror-1.com"},
+			},
+		},
+	}
+
+	// the registry mirrors are applied, and the cluster discovery fails
+	valuesSets := make(map[string]interface{})
+	valuesSets["labels"] = "foo:baz"
+	valuesSets["registry-mirrors"] = []string{"https://mirror-2.com"}
+	valuesSets["cluster-store"] = "memory://127.0.0.1"
+	valuesSets["cluster-advertise"] = "127.0.0.1"
+	newConfig := &Config{
+		CommonConfig: CommonConfig{
+			Labels:           []string{"foo:baz"},
+			ClusterStore:     "memory://127.0.0.1",
+			ClusterAdvertise: "127.0.0.1",
+			ServiceOptions: registry.ServiceOptions{
+				Mirrors: []string{"https://mirror-2.com"},
+			},
+			valuesSet: valuesSets,
+		},
+	}
+
+	if err := daemon.Reload(newConfig); err == nil {
+		t.Fatal("Expected an error for an invalid cluster advertise address")
+	}
+
+	if label := daemon.configStore.Labels[0]; label != "foo:bar" {
+		t.Fatalf("Expected daemon label `foo:bar`, got %s", label)
+	}
+	if mirrors := daemon.configStore.Mirrors; len(mirrors) != 1 || mirrors[0] != "https://mirror-1.com" {
+		t.Fatalf("Expected the configured mirrors to be kept, got %v", mirrors)
+	}
+	if mirrors := daemon.RegistryService.ServiceConfig().Mirrors; len(mirrors) != 1 || mirrors[0] != "https://mirror-1.com/" {
+		t.Fatalf("Expected the registry mirrors to be rolled back, got %v", mirrors)
+	}
+}
+
 func TestDaemonDiscoveryReload(t *testing.T) {
 	daemon := &Daemon{}
 	daemon.configStore = &Config{
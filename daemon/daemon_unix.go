@@ -4,6 +4,7 @@ package daemon
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -29,6 +31,7 @@ import (
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/docker/docker/runconfig"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
+	units "github.com/docker/go-units"
 	"github.com/docker/libnetwork"
 	nwconfig "github.com/docker/libnetwork/config"
 	"github.com/docker/libnetwork/drivers/bridge"
@@ -518,6 +521,12 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("SHM size can not be less than 0")
 	}
 
+	for _, rule := range hostConfig.DeviceCgroupRules {
+		if _, err := runconfigopts.ValidateDeviceCgroupRule(rule); err != nil {
+			return warnings, err
+		}
+	}
+
 	if hostConfig.OomScoreAdj < -1000 || hostConfig.OomScoreAdj > 1000 {
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
@@ -556,8 +565,24 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 	return warnings, nil
 }
 
+// platformReloadValidate validates the platform specific settings of a
+// configuration reload, before any of them is applied.
+func (daemon *Daemon) platformReloadValidate(config *Config) error {
+	if config.IsValueSet("default-ulimits") {
+		for name, ul := range config.Ulimits {
+			if ul == nil || ul.Name != name {
+				return fmt.Errorf("invalid default ulimit %q", name)
+			}
+			if _, err := units.ParseUlimit(ul.String()); err != nil {
+				return fmt.Errorf("invalid default ulimit %q: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // platformReload updates configuration with platform specific options
-func (daemon *Daemon) platformReload(config *Config) map[string]string {
+func (daemon *Daemon) platformReload(config *Config) {
 	if config.IsValueSet("runtimes") {
 		daemon.configStore.Runtimes = config.Runtimes
 		// Always set the default one
@@ -568,19 +593,43 @@ func (daemon *Daemon) platformReload(config *Config) map[string]string {
 		daemon.configStore.DefaultRuntime = config.DefaultRuntime
 	}
 
-	// Update attributes
+	if config.IsValueSet("default-ulimits") {
+		daemon.configStore.Ulimits = config.Ulimits
+	}
+}
+
+// platformReloadAttributes returns the platform specific attributes of the
+// daemon reload event.
+func (daemon *Daemon) platformReloadAttributes() (map[string]string, error) {
+	names := make([]string, 0, len(daemon.configStore.Runtimes))
+	for name := range daemon.configStore.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
 	var runtimeList bytes.Buffer
-	for name, rt := range daemon.configStore.Runtimes {
+	for _, name := range names {
 		if runtimeList.Len() > 0 {
 			runtimeList.WriteRune(' ')
 		}
-		runtimeList.WriteString(fmt.Sprintf("%s:%s", name, rt))
+		runtimeList.WriteString(fmt.Sprintf("%s:%s", name, daemon.configStore.Runtimes[name]))
+	}
+
+	ulimits := make([]string, 0, len(daemon.configStore.Ulimits))
+	for _, ul := range daemon.configStore.Ulimits {
+		ulimits = append(ulimits, ul.String())
+	}
+	sort.Strings(ulimits)
+	defaultUlimits, err := json.Marshal(ulimits)
+	if err != nil {
+		return nil, err
 	}
 
 	return map[string]string{
 		"runtimes":        runtimeList.String(),
 		"default-runtime": daemon.configStore.DefaultRuntime,
-	}
+		"default-ulimits": string(defaultUlimits),
+	}, nil
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
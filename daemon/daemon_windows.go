@@ -203,9 +203,20 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 	return warnings, err
 }
 
+// platformReloadValidate validates the platform specific settings of a
+// configuration reload, before any of them is applied.
+func (daemon *Daemon) platformReloadValidate(config *Config) error {
+	return nil
+}
+
 // platformReload updates configuration with platform specific options
-func (daemon *Daemon) platformReload(config *Config) map[string]string {
-	return map[string]string{}
+func (daemon *Daemon) platformReload(config *Config) {
+}
+
+// platformReloadAttributes returns the platform specific attributes of the
+// daemon reload event.
+func (daemon *Daemon) platformReloadAttributes() (map[string]string, error) {
+	return map[string]string{}, nil
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
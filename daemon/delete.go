@@ -117,7 +117,10 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 			if e := daemon.removeMountPoints(container, removeVolume); e != nil {
 				logrus.Error(e)
 			}
+			container.SetRemoved()
 			daemon.LogContainerEvent(container, "destroy")
+		} else {
+			container.SetRemovalError(err)
 		}
 	}()
 
@@ -165,13 +165,12 @@ func (d *daemonDiscoveryReloader) initHeartbeat(address string) error {
 
 // Reload makes the watcher to stop advertising and reconfigures it to advertise in a new address.
 func (d *daemonDiscoveryReloader) Reload(backendAddress, advertiseAddress string, clusterOpts map[string]string) error {
-	d.Stop()
-
 	heartbeat, backend, err := parseDiscoveryOptions(backendAddress, clusterOpts)
 	if err != nil {
 		return err
 	}
 
+	d.Stop()
 	d.backend = backend
 	d.ticker = time.NewTicker(heartbeat)
 	d.readyCh = make(chan struct{})
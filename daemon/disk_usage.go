@@ -57,12 +57,25 @@ func (daemon *Daemon) SystemDiskUsage() (*types.DiskUsage, error) {
 		refs := daemon.volumes.Refs(v)
 
 		tv := volumeToAPIType(v)
-		sz, err := directory.Size(v.Path())
-		if err != nil {
-			logrus.Warnf("failed to determine size of volume %v", name)
-			sz = -1
+		tv.UsageData = &types.VolumeUsageData{Size: -1, RefCount: int64(len(refs))}
+		if sv, ok := v.(volume.SizedVolume); ok {
+			limit, usage, err := sv.SizeLimit()
+			if err != nil {
+				logrus.Warnf("failed to determine size limit of volume %v: %v", name, err)
+			} else if limit > 0 {
+				// the quota already accounts for the disk space used
+				tv.UsageData.Limit = int64(limit)
+				tv.UsageData.Size = int64(usage)
+			}
+		}
+		if tv.UsageData.Limit == 0 {
+			sz, err := directory.Size(v.Path())
+			if err != nil {
+				logrus.Warnf("failed to determine size of volume %v", name)
+				sz = -1
+			}
+			tv.UsageData.Size = sz
 		}
-		tv.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(len(refs))}
 		allVolumes = append(allVolumes, tv)
 
 		return nil
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/pkg/pubsub"
 )
@@ -15,9 +16,10 @@ const (
 
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
-	mu     sync.Mutex
-	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	mu      sync.Mutex
+	events  []eventtypes.Message
+	pub     *pubsub.Publisher
+	journal *journalWriter
 }
 
 // New returns new *Events instance
@@ -28,6 +30,37 @@ func New() *Events {
 	}
 }
 
+// SetJournal makes the events persistent. Every event logged from now on is
+// also written to the journal, and past events are replayed from it
+// instead of the in-memory buffer. The journal is written to in the
+// background, so that logging an event doesn't wait for the disk.
+func (e *Events) SetJournal(j *Journal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.closeJournal()
+	e.journal = newJournalWriter(j)
+}
+
+// Close writes the pending events to the journal, if any, and closes it.
+// Events logged afterwards are only kept in memory.
+func (e *Events) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.closeJournal()
+}
+
+// closeJournal stops writing to the journal and closes it. It must be
+// called with e.mu held.
+func (e *Events) closeJournal() error {
+	if e.journal == nil {
+		return nil
+	}
+	err := e.journal.close()
+	e.journal = nil
+	return err
+}
+
 // Subscribe adds new listener to events, returns slice of 64 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -58,7 +91,21 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 		topic = func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
 	}
 
-	buffered := e.loadBufferedEvents(since, until, topic)
+	var (
+		buffered []eventtypes.Message
+		journal  *Journal
+		flushed  <-chan journalOffset
+	)
+	if e.journal != nil && !(since.IsZero() && until.IsZero()) {
+		// Reading the journal can take a while, so do it once the lock is
+		// released, as soon as the events logged so far are written. The
+		// events logged after subscribing are sent on the channel, so the
+		// replay stops where the journal was when they were written.
+		journal = e.journal.journal
+		flushed = e.journal.mark()
+	} else {
+		buffered = e.loadBufferedEvents(since, until, topic)
+	}
 
 	var ch chan interface{}
 	if topic != nil {
@@ -69,6 +116,18 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 	}
 
 	e.mu.Unlock()
+
+	if journal != nil {
+		end := <-flushed
+		var err error
+		buffered, err = journal.readTo(end, since, until, topic)
+		if err != nil {
+			logrus.Warnf("Error reading the events journal, falling back to buffered events: %v", err)
+			e.mu.Lock()
+			buffered = e.loadBufferedEvents(since, until, topic)
+			e.mu.Unlock()
+		}
+	}
 	return buffered, ch
 }
 
@@ -110,6 +169,9 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	} else {
 		e.events = append(e.events, jm)
 	}
+	if e.journal != nil {
+		e.journal.write(jm)
+	}
 	e.mu.Unlock()
 	e.pub.Publish(jm)
 }
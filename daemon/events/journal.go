@@ -0,0 +1,365 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	eventtypes "github.com/docker/docker/api/types/events"
+)
+
+const (
+	journalFileName = "events.log"
+	// journalFiles is the number of files the journal is split into. The
+	// oldest file is dropped when the current one is rotated.
+	journalFiles = 4
+	// maxJournalLine is the largest encoded event accepted when reading
+	// the journal back.
+	maxJournalLine = 1 << 20
+)
+
+// JournalConfig defines the retention of the on-disk events journal.
+type JournalConfig struct {
+	// MaxSize is the maximum number of bytes used by the journal on disk.
+	MaxSize int64
+	// MaxAge is the maximum age of the events kept in the journal. A zero
+	// value keeps events until they are evicted because of MaxSize.
+	MaxAge time.Duration
+}
+
+// Journal is an append-only log of events stored on disk. The events are
+// written as JSON, one per line, to a set of rotated files so the journal
+// never grows beyond its configured size.
+type Journal struct {
+	mu   sync.Mutex
+	root string
+	cfg  JournalConfig
+	f    *os.File
+	size int64
+	// rotations is the number of times the journal files were rotated
+	// since the journal was opened.
+	rotations int
+}
+
+// journalOffset is a position in the journal. It stays valid when the
+// journal files are rotated.
+type journalOffset struct {
+	rotations int
+	size      int64
+}
+
+// NewJournal opens the journal stored in root, creating it if needed.
+func NewJournal(root string, cfg JournalConfig) (*Journal, error) {
+	if cfg.MaxSize <= 0 {
+		return nil, fmt.Errorf("invalid events journal size: %d", cfg.MaxSize)
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+
+	j := &Journal{root: root, cfg: cfg}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	j.pruneExpired(time.Now())
+	return j, nil
+}
+
+func (j *Journal) path(i int) string {
+	if i == 0 {
+		return filepath.Join(j.root, journalFileName)
+	}
+	return filepath.Join(j.root, fmt.Sprintf("%s.%d", journalFileName, i))
+}
+
+func (j *Journal) open() error {
+	f, err := os.OpenFile(j.path(0), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	j.f = f
+	j.size = fi.Size()
+	return nil
+}
+
+// Write appends an event to the journal, rotating the journal files when
+// the current one is full.
+func (j *Journal) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return fmt.Errorf("events journal is closed")
+	}
+	if j.size > 0 && j.size+int64(len(b)) > j.cfg.MaxSize/journalFiles {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := j.f.Write(b)
+	j.size += int64(n)
+	return err
+}
+
+func (j *Journal) rotate() error {
+	if err := j.f.Close(); err != nil {
+		return err
+	}
+	j.f = nil
+
+	for i := journalFiles - 1; i > 0; i-- {
+		if err := os.Rename(j.path(i-1), j.path(i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	j.rotations++
+	if err := j.open(); err != nil {
+		return err
+	}
+	j.pruneExpired(time.Now())
+	return nil
+}
+
+// pruneExpired removes the rotated files that only hold events older than
+// the maximum age of the journal.
+func (j *Journal) pruneExpired(now time.Time) {
+	if j.cfg.MaxAge <= 0 {
+		return
+	}
+	for i := 1; i < journalFiles; i++ {
+		fi, err := os.Stat(j.path(i))
+		if err != nil {
+			continue
+		}
+		if now.Sub(fi.ModTime()) > j.cfg.MaxAge {
+			if err := os.Remove(j.path(i)); err != nil {
+				logrus.Warnf("Error removing expired events journal file %s: %v", j.path(i), err)
+			}
+		}
+	}
+}
+
+// offset returns the position right after the last event written to the
+// journal.
+func (j *Journal) offset() journalOffset {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return journalOffset{rotations: j.rotations, size: j.size}
+}
+
+// Read returns the events stored in the journal that were emitted between
+// since and until and match the topic function, if any, oldest first. A
+// zero until returns every event emitted after since.
+func (j *Journal) Read(since, until time.Time, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	return j.readTo(j.offset(), since, until, topic)
+}
+
+// readTo is like Read, but ignores the events written after the end offset.
+func (j *Journal) readTo(end journalOffset, since, until time.Time, topic func(interface{}) bool) ([]eventtypes.Message, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var sinceNanoUnix, untilNanoUnix int64
+	if !since.IsZero() {
+		sinceNanoUnix = since.UnixNano()
+	}
+	if !until.IsZero() {
+		untilNanoUnix = until.UnixNano()
+	}
+	if j.cfg.MaxAge > 0 {
+		if expired := time.Now().Add(-j.cfg.MaxAge).UnixNano(); expired > sinceNanoUnix {
+			sinceNanoUnix = expired
+		}
+	}
+
+	// the file holding the end offset, the files after it only hold events
+	// written after the end offset
+	last := j.rotations - end.rotations
+
+	var messages []eventtypes.Message
+	for i := journalFiles - 1; i >= 0 && i >= last; i-- {
+		f, err := os.Open(j.path(i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		var r io.Reader = f
+		if i == last {
+			r = io.LimitReader(f, end.size)
+		}
+		s := bufio.NewScanner(r)
+		s.Buffer(make([]byte, 4096), maxJournalLine)
+		for s.Scan() {
+			var ev eventtypes.Message
+			if err := json.Unmarshal(s.Bytes(), &ev); err != nil {
+				// a partially written event is expected if the daemon
+				// died while writing to the journal
+				logrus.Debugf("Skipping invalid entry in events journal %s: %v", j.path(i), err)
+				continue
+			}
+			if ev.TimeNano < sinceNanoUnix {
+				continue
+			}
+			if untilNanoUnix > 0 && ev.TimeNano > untilNanoUnix {
+				continue
+			}
+			if topic == nil || topic(ev) {
+				messages = append(messages, ev)
+			}
+		}
+		err = s.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+// Close closes the journal. Events can't be written to a closed journal.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.f == nil {
+		return nil
+	}
+	err := j.f.Close()
+	j.f = nil
+	return err
+}
+
+// journalEntry is either an event to write to the journal, or a marker
+// that receives the offset of the journal once all the events queued
+// before it are written.
+type journalEntry struct {
+	msg     eventtypes.Message
+	flushed chan journalOffset
+}
+
+// journalWriter writes events to a journal in the background, so that
+// logging an event never waits for the disk.
+type journalWriter struct {
+	journal *Journal
+
+	mu sync.Mutex
+	// queue holds the entries waiting to be written, in the order they
+	// were logged. At most bufferSize events are queued, the events
+	// logged while the queue is full are not written to the journal.
+	queue    []journalEntry
+	events   int
+	dropping bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newJournalWriter(j *Journal) *journalWriter {
+	w := &journalWriter{
+		journal: j,
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// write queues an event to write to the journal. The event is dropped if
+// the journal is too far behind.
+func (w *journalWriter) write(m eventtypes.Message) {
+	w.mu.Lock()
+	if w.events >= bufferSize {
+		if !w.dropping {
+			logrus.Warn("Events journal is falling behind, events are not written to it until it catches up")
+			w.dropping = true
+		}
+		w.mu.Unlock()
+		eventsJournalDropped.Inc()
+		return
+	}
+	w.dropping = false
+	w.queue = append(w.queue, journalEntry{msg: m})
+	w.events++
+	w.mu.Unlock()
+	w.notify()
+}
+
+// mark queues a marker, and returns a channel that receives the offset of
+// the journal once all the events queued before the marker are written.
+func (w *journalWriter) mark() <-chan journalOffset {
+	flushed := make(chan journalOffset, 1)
+	w.mu.Lock()
+	w.queue = append(w.queue, journalEntry{flushed: flushed})
+	w.mu.Unlock()
+	w.notify()
+	return flushed
+}
+
+func (w *journalWriter) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *journalWriter) run() {
+	defer close(w.done)
+	for {
+		select {
+		case <-w.wake:
+			w.flush()
+		case <-w.stop:
+			w.flush()
+			return
+		}
+	}
+}
+
+// flush writes the queued entries to the journal.
+func (w *journalWriter) flush() {
+	w.mu.Lock()
+	queue := w.queue
+	w.queue = nil
+	w.events = 0
+	w.mu.Unlock()
+
+	for _, entry := range queue {
+		if entry.flushed != nil {
+			entry.flushed <- w.journal.offset()
+			continue
+		}
+		if err := w.journal.Write(entry.msg); err != nil {
+			logrus.Warnf("Error writing event to the events journal: %v", err)
+		}
+	}
+}
+
+// close writes the queued events to the journal and closes it.
+func (w *journalWriter) close() error {
+	close(w.stop)
+	<-w.done
+	return w.journal.Close()
+}
@@ -0,0 +1,322 @@
+package events
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	eventtypes "github.com/docker/docker/api/types/events"
+)
+
+func newTestJournal(t *testing.T, cfg JournalConfig) (*Journal, string) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := NewJournal(root, cfg)
+	if err != nil {
+		os.RemoveAll(root)
+		t.Fatal(err)
+	}
+	return j, root
+}
+
+func testJournalMessage(id string, ts time.Time) eventtypes.Message {
+	return eventtypes.Message{
+		Type:     eventtypes.ContainerEventType,
+		Action:   "start",
+		Actor:    eventtypes.Actor{ID: id},
+		Time:     ts.Unix(),
+		TimeNano: ts.UnixNano(),
+	}
+}
+
+func TestJournalReadSinceUntil(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20})
+	defer os.RemoveAll(root)
+	defer j.Close()
+
+	now := time.Now()
+	for i, id := range []string{"a", "b", "c", "d"} {
+		if err := j.Write(testJournalMessage(id, now.Add(time.Duration(i)*time.Second))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	messages, err := j.Read(now.Add(time.Second), now.Add(2*time.Second), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0].Actor.ID != "b" || messages[1].Actor.ID != "c" {
+		t.Fatalf("expected events b and c, got %v", messages)
+	}
+
+	topic := func(m interface{}) bool { return m.(eventtypes.Message).Actor.ID == "d" }
+	messages, err = j.Read(now, time.Time{}, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || messages[0].Actor.ID != "d" {
+		t.Fatalf("expected event d, got %v", messages)
+	}
+}
+
+func TestJournalReopen(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20})
+	defer os.RemoveAll(root)
+
+	now := time.Now()
+	if err := j.Write(testJournalMessage("a", now)); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	// simulate an event partially written before the daemon died
+	f, err := os.OpenFile(j.path(0), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"Type":"contai` + "\n")
+	f.Close()
+
+	j, err = NewJournal(root, JournalConfig{MaxSize: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if err := j.Write(testJournalMessage("b", now.Add(time.Second))); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := j.Read(now.Add(-time.Second), time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || messages[0].Actor.ID != "a" || messages[1].Actor.ID != "b" {
+		t.Fatalf("expected events a and b, got %v", messages)
+	}
+}
+
+func TestJournalMaxSize(t *testing.T) {
+	const maxSize = 4096
+	j, root := newTestJournal(t, JournalConfig{MaxSize: maxSize})
+	defer os.RemoveAll(root)
+	defer j.Close()
+
+	start := time.Now()
+	for i := 0; i < 500; i++ {
+		m := testJournalMessage(strings.Repeat("x", 32), start.Add(time.Duration(i)*time.Millisecond))
+		if err := j.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var size int64
+	files, err := ioutil.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != journalFiles {
+		t.Fatalf("expected %d journal files, got %d", journalFiles, len(files))
+	}
+	for _, fi := range files {
+		size += fi.Size()
+	}
+	if size > maxSize {
+		t.Fatalf("expected journal to use at most %d bytes, got %d", maxSize, size)
+	}
+
+	messages, err := j.Read(start, time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) == 0 || len(messages) >= 500 {
+		t.Fatalf("expected old events to be evicted, got %d events", len(messages))
+	}
+	last := messages[len(messages)-1]
+	if last.TimeNano != start.Add(499*time.Millisecond).UnixNano() {
+		t.Fatalf("expected the last event to be kept, got %v", last)
+	}
+}
+
+func TestJournalReadTo(t *testing.T) {
+	const maxSize = 4096
+	j, root := newTestJournal(t, JournalConfig{MaxSize: maxSize})
+	defer os.RemoveAll(root)
+	defer j.Close()
+
+	start := time.Now()
+	write := func(i int) {
+		m := testJournalMessage(fmt.Sprintf("%03d", i), start.Add(time.Duration(i)*time.Millisecond))
+		if err := j.Write(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		write(i)
+	}
+	end := j.offset()
+	// rotate the file holding the end offset
+	for i := 10; i < 30; i++ {
+		write(i)
+	}
+	if j.rotations == end.rotations {
+		t.Fatal("expected the journal to be rotated")
+	}
+
+	messages, err := j.readTo(end, start, time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 10 || messages[9].Actor.ID != "009" {
+		t.Fatalf("expected the events written before the end offset, got %v", messages)
+	}
+}
+
+func TestJournalMaxAge(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20, MaxAge: time.Hour})
+	defer os.RemoveAll(root)
+	defer j.Close()
+
+	now := time.Now()
+	if err := j.Write(testJournalMessage("old", now.Add(-2*time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Write(testJournalMessage("new", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := j.Read(now.Add(-3*time.Hour), time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || messages[0].Actor.ID != "new" {
+		t.Fatalf("expected only the new event, got %v", messages)
+	}
+
+	// rotated files only holding expired events are removed
+	j.mu.Lock()
+	if err := j.rotate(); err != nil {
+		j.mu.Unlock()
+		t.Fatal(err)
+	}
+	j.mu.Unlock()
+	old := now.Add(-2 * time.Hour)
+	if err := os.Chtimes(j.path(1), old, old); err != nil {
+		t.Fatal(err)
+	}
+	j.pruneExpired(now)
+	if _, err := os.Stat(j.path(1)); !os.IsNotExist(err) {
+		t.Fatalf("expected expired journal file to be removed, got %v", err)
+	}
+}
+
+func TestEventsReplayFromJournal(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20})
+	defer os.RemoveAll(root)
+
+	since := time.Now()
+	e := New()
+	e.SetJournal(j)
+	e.Log("start", eventtypes.ContainerEventType, eventtypes.Actor{ID: "a"})
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new Events instance, as created after a daemon restart, replays
+	// the events from the journal
+	j, err := NewJournal(root, JournalConfig{MaxSize: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = New()
+	e.SetJournal(j)
+	defer e.Close()
+	e.Log("stop", eventtypes.ContainerEventType, eventtypes.Actor{ID: "a"})
+
+	messages, ch := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(ch)
+	if len(messages) != 2 || messages[0].Action != "start" || messages[1].Action != "stop" {
+		t.Fatalf("expected start and stop events, got %v", messages)
+	}
+}
+
+func TestEventsJournal(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20})
+	defer os.RemoveAll(root)
+
+	e := New()
+	e.SetJournal(j)
+
+	since := time.Now().Add(-time.Second)
+	for _, id := range []string{"a", "b", "c"} {
+		e.Log("start", eventtypes.ContainerEventType, eventtypes.Actor{ID: id})
+	}
+
+	// events logged before subscribing are replayed from the journal, even
+	// if they were not written yet when subscribing
+	replayed, l := e.SubscribeTopic(since, time.Time{}, nil)
+	e.Evict(l)
+	if len(replayed) != 3 || replayed[0].Actor.ID != "a" || replayed[2].Actor.ID != "c" {
+		t.Fatalf("expected events a, b and c, got %v", replayed)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// events logged after closing are only kept in memory
+	e.Log("start", eventtypes.ContainerEventType, eventtypes.Actor{ID: "d"})
+
+	j, err := NewJournal(root, JournalConfig{MaxSize: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	messages, err := j.Read(since, time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 events in the journal, got %v", messages)
+	}
+}
+
+func TestJournalWriterDropsWhenFull(t *testing.T) {
+	j, root := newTestJournal(t, JournalConfig{MaxSize: 1 << 20})
+	defer os.RemoveAll(root)
+
+	// not started, so that nothing is written until it is closed
+	w := &journalWriter{
+		journal: j,
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	start := time.Now()
+	for i := 0; i < bufferSize+10; i++ {
+		w.write(testJournalMessage("a", start))
+	}
+	flushed := w.mark()
+
+	go w.run()
+	end := <-flushed
+	if err := w.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := NewJournal(root, JournalConfig{MaxSize: 1 << 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	messages, err := j.readTo(end, start, time.Time{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != bufferSize {
+		t.Fatalf("expected %d events in the journal, got %d", bufferSize, len(messages))
+	}
+}
@@ -3,13 +3,15 @@ package events
 import "github.com/docker/go-metrics"
 
 var (
-	eventsCounter    metrics.Counter
-	eventSubscribers metrics.Gauge
+	eventsCounter        metrics.Counter
+	eventSubscribers     metrics.Gauge
+	eventsJournalDropped metrics.Counter
 )
 
 func init() {
 	ns := metrics.NewNamespace("engine", "daemon", nil)
 	eventsCounter = ns.NewCounter("events", "The number of events logged")
 	eventSubscribers = ns.NewGauge("events_subscribers", "The number of current subscribers to events", metrics.Total)
+	eventsJournalDropped = ns.NewCounter("events_journal_dropped", "The number of events not written to the events journal because it was falling behind")
 	metrics.Register(ns)
 }
@@ -1,4 +1,4 @@
-// +build linux
+// +build linux,cgo
 
 //
 // projectquota.go - implements XFS project quota controls
@@ -55,20 +55,17 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"unsafe"
 
 	"github.com/Sirupsen/logrus"
 )
 
-// Quota limit params - currently we only control blocks hard limit
-type Quota struct {
-	Size uint64
-}
-
 // Control - Context to be used by storage driver (e.g. overlay)
 // who wants to apply project quotas to container dirs
 type Control struct {
+	mu                sync.Mutex
 	backingFsBlockDev string
 	nextProjectID     uint32
 	quotas            map[string]uint32
@@ -106,22 +103,13 @@ func NewControl(basePath string) (*Control, error) {
 	}
 	minProjectID++
 
-	//
-	// create backing filesystem device node
-	//
-	backingFsBlockDev, err := makeBackingFsDev(basePath)
-	if err != nil {
-		return nil, err
-	}
-
 	//
 	// Test if filesystem supports project quotas by trying to set
-	// a quota on the first available project id
+	// a quota on the first available project id, and get the backing
+	// filesystem device node to use for quota controls
 	//
-	quota := Quota{
-		Size: 0,
-	}
-	if err := setProjectQuota(backingFsBlockDev, minProjectID, quota); err != nil {
+	backingFsBlockDev, err := makeBackingFsDev(basePath, minProjectID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -146,6 +134,8 @@ func NewControl(basePath string) (*Control, error) {
 // SetQuota - assign a unique project id to directory and set the quota limits
 // for that project id
 func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
@@ -195,12 +185,53 @@ func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) er
 	return nil
 }
 
+// ClearQuota - remove the quota limits of a directory that was configured
+// with SetQuota, and forget about its project id. It is used when the
+// directory is removed, so that a directory later created at the same path
+// gets a new project id.
+func (q *Control) ClearQuota(targetPath string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	projectID, ok := q.quotas[targetPath]
+	if !ok {
+		return nil
+	}
+	if err := setProjectQuota(q.backingFsBlockDev, projectID, Quota{Size: 0}); err != nil {
+		return err
+	}
+	delete(q.quotas, targetPath)
+	return nil
+}
+
 // GetQuota - get the quota limits of a directory that was configured with SetQuota
 func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	d, err := q.getDiskQuota(targetPath)
+	if err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
+	return nil
+}
+
+// GetUsage - get the disk space used by a directory that was configured with
+// SetQuota
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	d, err := q.getDiskQuota(targetPath)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(d.d_bcount) * 512, nil
+}
 
+// getDiskQuota - get the quota limits and usage of the project id of a
+// directory
+func (q *Control) getDiskQuota(targetPath string) (*C.fs_disk_quota_t, error) {
+	q.mu.Lock()
 	projectID, ok := q.quotas[targetPath]
+	q.mu.Unlock()
 	if !ok {
-		return fmt.Errorf("quota not found for path : %s", targetPath)
+		return nil, fmt.Errorf("quota not found for path : %s", targetPath)
 	}
 
 	//
@@ -215,12 +246,10 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
 		uintptr(unsafe.Pointer(&d)), 0, 0)
 	if errno != 0 {
-		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
+		return nil, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
 			projectID, q.backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
-
-	return nil
+	return &d, nil
 }
 
 // getProjectID - get the project id of path on xfs
@@ -320,19 +349,44 @@ func getDirFd(dir *C.DIR) uintptr {
 
 // Get the backing block device of the driver home directory
 // and create a block device node under the home directory
-// to be used by quotactl commands
-func makeBackingFsDev(home string) (string, error) {
+// to be used by quotactl commands. An existing node for the
+// right device is reused. A new node is only kept once project
+// quotas are confirmed to be supported, by setting a quota on
+// the given project id.
+func makeBackingFsDev(home string, projectID uint32) (string, error) {
 	fileinfo, err := os.Stat(home)
 	if err != nil {
 		return "", err
 	}
+	dev := fileinfo.Sys().(*syscall.Stat_t).Dev
+
+	quota := Quota{
+		Size: 0,
+	}
 
 	backingFsBlockDev := path.Join(home, "backingFsBlockDev")
+	var nodeStat syscall.Stat_t
+	if err := syscall.Lstat(backingFsBlockDev, &nodeStat); err == nil &&
+		nodeStat.Mode&syscall.S_IFMT == syscall.S_IFBLK && nodeStat.Rdev == dev {
+		if err := setProjectQuota(backingFsBlockDev, projectID, quota); err != nil {
+			return "", err
+		}
+		return backingFsBlockDev, nil
+	}
+
 	// Re-create just in case comeone copied the home directory over to a new device
-	syscall.Unlink(backingFsBlockDev)
-	stat := fileinfo.Sys().(*syscall.Stat_t)
-	if err := syscall.Mknod(backingFsBlockDev, syscall.S_IFBLK|0600, int(stat.Dev)); err != nil {
-		return "", fmt.Errorf("Failed to mknod %s: %v", backingFsBlockDev, err)
+	backingFsBlockDevTmp := backingFsBlockDev + ".tmp"
+	syscall.Unlink(backingFsBlockDevTmp)
+	if err := syscall.Mknod(backingFsBlockDevTmp, syscall.S_IFBLK|0600, int(dev)); err != nil {
+		return "", fmt.Errorf("Failed to mknod %s: %v", backingFsBlockDevTmp, err)
+	}
+	if err := setProjectQuota(backingFsBlockDevTmp, projectID, quota); err != nil {
+		syscall.Unlink(backingFsBlockDevTmp)
+		return "", err
+	}
+	if err := os.Rename(backingFsBlockDevTmp, backingFsBlockDev); err != nil {
+		syscall.Unlink(backingFsBlockDevTmp)
+		return "", err
 	}
 
 	return backingFsBlockDev, nil
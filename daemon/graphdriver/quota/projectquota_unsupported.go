@@ -0,0 +1,39 @@
+// +build !linux !cgo
+
+package quota
+
+import "errors"
+
+// errNotSupported is returned by NewControl on platforms without project
+// quota support.
+var errNotSupported = errors.New("project quotas are not supported on this platform")
+
+// Control - Context to be used by storage driver (e.g. overlay)
+// who wants to apply project quotas to container dirs
+type Control struct{}
+
+// NewControl - project quotas are not supported on this platform, so an
+// error is always returned.
+func NewControl(basePath string) (*Control, error) {
+	return nil, errNotSupported
+}
+
+// SetQuota - not supported on this platform
+func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	return errNotSupported
+}
+
+// ClearQuota - not supported on this platform
+func (q *Control) ClearQuota(targetPath string) error {
+	return errNotSupported
+}
+
+// GetQuota - not supported on this platform
+func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	return errNotSupported
+}
+
+// GetUsage - not supported on this platform
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	return 0, errNotSupported
+}
@@ -3,12 +3,18 @@ package daemon
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
@@ -34,8 +40,15 @@ const (
 	// for the container to be considered unhealthy.
 	defaultProbeRetries = 3
 
+	// Default time after the container starts during which failing probes
+	// are not counted towards the retries.
+	defaultStartPeriod = 0 * time.Second
+
 	// Maximum number of entries to record
 	maxLogEntries = 5
+
+	// Range of status codes that HTTP probes consider healthy by default.
+	defaultHTTPStatusRange = "200-399"
 )
 
 const (
@@ -103,6 +116,192 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, container *container.Cont
 	}, nil
 }
 
+// httpProbe implements the "HTTP" probe type. It sends a GET request to the
+// configured URL from within the container's network namespace.
+type httpProbe struct{}
+
+func (p *httpProbe) run(ctx context.Context, d *Daemon, c *container.Container) (*types.HealthcheckResult, error) {
+	target, minStatus, maxStatus, err := parseHTTPProbe(c.Config.Healthcheck.Test)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(network, address string) (net.Conn, error) {
+				return dialInContainer(ctx, c, network, address)
+			},
+			DisableKeepAlives: true,
+		},
+		// The status of the health endpoint itself is what is checked, so
+		// redirects are not followed.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequest("GET", target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Docker-Healthcheck")
+
+	resp, err := ctxhttp.Do(ctx, client, req)
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	output := &limitedBuffer{}
+	fmt.Fprintf(output, "GET %s: %s\n", target, resp.Status)
+	if _, err := io.Copy(output, resp.Body); err != nil {
+		logrus.Debugf("Health check for container %s: error reading response body: %v", c.ID, err)
+	}
+
+	exitCode := exitStatusHealthy
+	if resp.StatusCode < minStatus || resp.StatusCode > maxStatus {
+		exitCode = exitStatusUnhealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   output.String(),
+	}, nil
+}
+
+// tcpProbe implements the "TCP" probe type. It opens a TCP connection to the
+// configured address from within the container's network namespace.
+type tcpProbe struct{}
+
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, c *container.Container) (*types.HealthcheckResult, error) {
+	address, err := parseTCPProbe(c.Config.Healthcheck.Test)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialInContainer(ctx, c, "tcp", address)
+	if err != nil {
+		return &types.HealthcheckResult{
+			End:      time.Now(),
+			ExitCode: exitStatusUnhealthy,
+			Output:   err.Error(),
+		}, nil
+	}
+	conn.Close()
+
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   fmt.Sprintf("Connected to %s", address),
+	}, nil
+}
+
+// parseHTTPProbe parses the arguments of an "HTTP" health check test, which
+// are the URL to probe and an optional range of healthy status codes, such
+// as "200" or "200-299".
+func parseHTTPProbe(test []string) (*url.URL, int, int, error) {
+	if len(test) < 2 || len(test) > 3 {
+		return nil, 0, 0, fmt.Errorf("HTTP health check requires a URL and an optional status range")
+	}
+	target, err := url.Parse(test[1])
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid HTTP health check URL %q: %v", test[1], err)
+	}
+	if target.Scheme != "http" || target.Host == "" {
+		return nil, 0, 0, fmt.Errorf("invalid HTTP health check URL %q: must be of the form http://host[:port][/path]", test[1])
+	}
+	host := target.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if err := checkProbeHost(strings.Trim(host, "[]")); err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid HTTP health check URL %q: %v", test[1], err)
+	}
+
+	statusRange := defaultHTTPStatusRange
+	if len(test) == 3 {
+		statusRange = test[2]
+	}
+	minStatus, maxStatus, err := parseStatusRange(statusRange)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return target, minStatus, maxStatus, nil
+}
+
+func parseStatusRange(statusRange string) (int, int, error) {
+	parts := strings.SplitN(statusRange, "-", 2)
+	minStatus, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid HTTP status range %q", statusRange)
+	}
+	maxStatus := minStatus
+	if len(parts) == 2 {
+		if maxStatus, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("invalid HTTP status range %q", statusRange)
+		}
+	}
+	if minStatus < 100 || maxStatus > 599 || minStatus > maxStatus {
+		return 0, 0, fmt.Errorf("invalid HTTP status range %q", statusRange)
+	}
+	return minStatus, maxStatus, nil
+}
+
+// parseTCPProbe parses the argument of a "TCP" health check test, which is
+// a "host:port" address, optionally prefixed with "tcp://".
+func parseTCPProbe(test []string) (string, error) {
+	if len(test) != 2 {
+		return "", fmt.Errorf("TCP health check requires exactly one address")
+	}
+	address := strings.TrimPrefix(test[1], "tcp://")
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || port == "" {
+		return "", fmt.Errorf("invalid TCP health check address %q: must be of the form [tcp://]host:port", test[1])
+	}
+	if err := checkProbeHost(host); err != nil {
+		return "", fmt.Errorf("invalid TCP health check address %q: %v", test[1], err)
+	}
+	return address, nil
+}
+
+// checkProbeHost checks that the host probed by a health check is an IP
+// address or localhost. Host names are not supported, as they would be
+// resolved with the resolver configuration of the host instead of the one of
+// the container.
+func checkProbeHost(host string) error {
+	if host == "localhost" || net.ParseIP(host) != nil {
+		return nil
+	}
+	return fmt.Errorf("host must be an IP address or localhost, got %q", host)
+}
+
+// validateHealthcheck checks the start period, and the arguments of the
+// health check probe types that the daemon runs itself.
+func validateHealthcheck(config *containertypes.HealthConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.StartPeriod < 0 {
+		return fmt.Errorf("StartPeriod in Healthcheck cannot be negative")
+	}
+	if len(config.Test) == 0 {
+		return nil
+	}
+	switch config.Test[0] {
+	case "HTTP":
+		_, _, _, err := parseHTTPProbe(config.Test)
+		return err
+	case "TCP":
+		_, err := parseTCPProbe(config.Test)
+		return err
+	}
+	return nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -134,9 +333,23 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 		h.Status = types.Healthy
 	} else {
 		// Failure (including invalid exit code)
-		h.FailingStreak++
-		if h.FailingStreak >= retries {
-			h.Status = types.Unhealthy
+		shouldIncrementStreak := true
+
+		// While the container is starting (i.e. no probe has succeeded
+		// yet), failures within the start period are recorded but not
+		// counted towards the retries.
+		if h.Status == types.Starting {
+			startPeriod := timeoutWithDefault(c.Config.Healthcheck.StartPeriod, defaultStartPeriod)
+			if result.Start.Sub(c.State.StartedAt) < startPeriod {
+				shouldIncrementStreak = false
+			}
+		}
+
+		if shouldIncrementStreak {
+			h.FailingStreak++
+			if h.FailingStreak >= retries {
+				h.Status = types.Unhealthy
+			}
 		}
 		// Else we're starting or healthy. Stay in that state.
 	}
@@ -219,8 +432,12 @@ func getProbe(c *container.Container) probe {
 		return &cmdProbe{shell: false}
 	case "CMD-SHELL":
 		return &cmdProbe{shell: true}
+	case "HTTP":
+		return &httpProbe{}
+	case "TCP":
+		return &tcpProbe{}
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD', 'HTTP' or 'TCP') in container %s", config.Test[0], c.ID)
 		return nil
 	}
 }
@@ -0,0 +1,96 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/vishvananda/netns"
+	"golang.org/x/net/context"
+)
+
+// dialInContainer connects to address from within the network namespace of
+// the container, so that probes reach services that only listen on the
+// loopback interface of the container. The host of the address must be an IP
+// address or localhost, as host names would be resolved with the resolver
+// configuration of the host.
+func dialInContainer(ctx context.Context, c *container.Container, network, address string) (net.Conn, error) {
+	pid := c.GetPID()
+	if pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if host == "localhost" {
+		address = net.JoinHostPort("127.0.0.1", port)
+	} else if net.ParseIP(host) == nil {
+		return nil, fmt.Errorf("cannot resolve %s from within container %s: host must be an IP address or localhost", host, c.ID)
+	}
+
+	containerNs, err := netns.GetFromPath(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network namespace of container %s: %v", c.ID, err)
+	}
+	defer containerNs.Close()
+
+	// The namespace is a property of the OS thread, so it is switched on a
+	// dedicated goroutine locked to its thread. If the original namespace
+	// cannot be restored, that goroutine blocks forever with its thread
+	// still locked, so that the thread is never reused by the runtime while
+	// in the namespace of the container.
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	result := make(chan dialResult, 1)
+	go func() {
+		runtime.LockOSThread()
+		conn, restored, err := dialInNamespace(ctx, containerNs, network, address)
+		result <- dialResult{conn: conn, err: err}
+		if !restored {
+			logrus.Errorf("Failed to restore the network namespace of a health check thread, the thread is left unused: %v", err)
+			select {}
+		}
+		runtime.UnlockOSThread()
+	}()
+	r := <-result
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.conn, nil
+}
+
+// dialInNamespace connects to address from within the given network
+// namespace, and switches back to the original namespace of the thread. It
+// must be called with the goroutine locked to its thread, and the thread
+// must not be unlocked if the original namespace was not restored.
+func dialInNamespace(ctx context.Context, ns netns.NsHandle, network, address string) (conn net.Conn, restored bool, err error) {
+	hostNs, err := netns.Get()
+	if err != nil {
+		return nil, true, err
+	}
+	defer hostNs.Close()
+
+	if err := netns.Set(ns); err != nil {
+		return nil, true, fmt.Errorf("failed to enter network namespace: %v", err)
+	}
+
+	dialer := &net.Dialer{}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+	conn, dialErr := dialer.Dial(network, address)
+
+	if err := netns.Set(hostNs); err != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return nil, false, fmt.Errorf("failed to restore network namespace: %v", err)
+	}
+	return conn, true, dialErr
+}
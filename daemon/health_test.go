@@ -116,4 +116,95 @@ func TestHealthStates(t *testing.T) {
 	if c.State.Health.FailingStreak != 0 {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
+
+	// Test start period
+
+	reset(c)
+	c.Config.Healthcheck.Retries = 2
+	c.Config.Healthcheck.StartPeriod = 30 * time.Second
+
+	handleResult(c.State.StartedAt.Add(20*time.Second), 1)
+	if c.State.Health.Status != types.Starting {
+		t.Errorf("Expecting starting, but got %#v\n", c.State.Health.Status)
+	}
+	if c.State.Health.FailingStreak != 0 {
+		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
+	}
+	if len(c.State.Health.Log) != 1 {
+		t.Errorf("Expecting the failure to be logged, but got %d log entries\n", len(c.State.Health.Log))
+	}
+	handleResult(c.State.StartedAt.Add(50*time.Second), 1)
+	if c.State.Health.FailingStreak != 1 {
+		t.Errorf("Expecting FailingStreak=1, but got %d\n", c.State.Health.FailingStreak)
+	}
+	handleResult(c.State.StartedAt.Add(80*time.Second), 1)
+	expect("health_status: unhealthy")
+
+	// A success ends the start period early
+
+	reset(c)
+	handleResult(c.State.StartedAt.Add(10*time.Second), 0)
+	expect("health_status: healthy")
+	handleResult(c.State.StartedAt.Add(15*time.Second), 1)
+	if c.State.Health.FailingStreak != 1 {
+		t.Errorf("Expecting FailingStreak=1, but got %d\n", c.State.Health.FailingStreak)
+	}
+	handleResult(c.State.StartedAt.Add(20*time.Second), 1)
+	expect("health_status: unhealthy")
+}
+
+func TestParseHTTPProbe(t *testing.T) {
+	target, minStatus, maxStatus, err := parseHTTPProbe([]string{"HTTP", "http://localhost:8080/health"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.String() != "http://localhost:8080/health" || minStatus != 200 || maxStatus != 399 {
+		t.Fatalf("unexpected result: %s %d-%d", target, minStatus, maxStatus)
+	}
+
+	_, minStatus, maxStatus, err = parseHTTPProbe([]string{"HTTP", "http://localhost/", "204"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minStatus != 204 || maxStatus != 204 {
+		t.Fatalf("expected status range 204-204, got %d-%d", minStatus, maxStatus)
+	}
+
+	invalid := [][]string{
+		{"HTTP"},
+		{"HTTP", "localhost:8080"},
+		{"HTTP", "https://localhost/"},
+		{"HTTP", "http://localhost/", "2xx"},
+		{"HTTP", "http://localhost/", "399-200"},
+		{"HTTP", "http://localhost/", "200-700"},
+		{"HTTP", "http://localhost/", "200", "extra"},
+		{"HTTP", "http://example.com/health"},
+	}
+	for _, test := range invalid {
+		if _, _, _, err := parseHTTPProbe(test); err == nil {
+			t.Fatalf("expected an error for %v", test)
+		}
+	}
+}
+
+func TestParseTCPProbe(t *testing.T) {
+	for _, test := range [][]string{{"TCP", "localhost:5432"}, {"TCP", "tcp://localhost:5432"}} {
+		address, err := parseTCPProbe(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if address != "localhost:5432" {
+			t.Fatalf("expected localhost:5432, got %s", address)
+		}
+	}
+
+	if _, err := parseTCPProbe([]string{"TCP", "[::1]:5432"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range [][]string{{"TCP"}, {"TCP", "localhost"}, {"TCP", "localhost:"}, {"TCP", "a:1", "b:2"}, {"TCP", "db:5432"}} {
+		if _, err := parseTCPProbe(test); err == nil {
+			t.Fatalf("expected an error for %v", test)
+		}
+	}
 }
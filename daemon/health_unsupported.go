@@ -0,0 +1,15 @@
+// +build !linux
+
+package daemon
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/docker/docker/container"
+	"golang.org/x/net/context"
+)
+
+func dialInContainer(ctx context.Context, c *container.Container, network, address string) (net.Conn, error) {
+	return nil, fmt.Errorf("HTTP and TCP health checks are not supported on this platform")
+}
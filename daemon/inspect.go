@@ -130,18 +130,19 @@ func (daemon *Daemon) getInspectData(container *container.Container, size bool)
 	}
 
 	containerState := &types.ContainerState{
-		Status:     container.State.StateString(),
-		Running:    container.State.Running,
-		Paused:     container.State.Paused,
-		Restarting: container.State.Restarting,
-		OOMKilled:  container.State.OOMKilled,
-		Dead:       container.State.Dead,
-		Pid:        container.State.Pid,
-		ExitCode:   container.State.ExitCode(),
-		Error:      container.State.Error(),
-		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
-		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
-		Health:     containerHealth,
+		Status:       container.State.StateString(),
+		Running:      container.State.Running,
+		Paused:       container.State.Paused,
+		Restarting:   container.State.Restarting,
+		CrashLooping: container.State.CrashLooping,
+		OOMKilled:    container.State.OOMKilled,
+		Dead:         container.State.Dead,
+		Pid:          container.State.Pid,
+		ExitCode:     container.State.ExitCode(),
+		Error:        container.State.Error(),
+		StartedAt:    container.State.StartedAt.Format(time.RFC3339Nano),
+		FinishedAt:   container.State.FinishedAt.Format(time.RFC3339Nano),
+		Health:       containerHealth,
 	}
 
 	contJSONBase := &types.ContainerJSONBase{
@@ -38,6 +38,7 @@ var acceptedPsFilterTags = map[string]bool{
 	"volume":    true,
 	"network":   true,
 	"is-task":   true,
+	"crashloop": true,
 }
 
 // iterationAction represents possible outcomes happening during the container iteration.
@@ -89,6 +90,10 @@ type listContext struct {
 	taskFilter bool
 	// isTask tells us if the we should filter container that are a task (true) or not (false)
 	isTask bool
+	// crashLoopFilter tells if we should filter based on whether a container is in a crash loop
+	crashLoopFilter bool
+	// crashLooping tells us if we should filter containers that are in a crash loop (true) or not (false)
+	crashLooping bool
 	// ContainerListOptions is the filters set by the user
 	*types.ContainerListOptions
 }
@@ -262,6 +267,19 @@ func (daemon *Daemon) foldFilter(config *types.ContainerListOptions) (*listConte
 		}
 	}
 
+	var crashLoopFilter, crashLooping bool
+	if psFilters.Include("crashloop") {
+		if psFilters.ExactMatch("crashloop", "true") {
+			crashLoopFilter = true
+			crashLooping = true
+		} else if psFilters.ExactMatch("crashloop", "false") {
+			crashLoopFilter = true
+			crashLooping = false
+		} else {
+			return nil, fmt.Errorf("Invalid filter 'crashloop=%s'", psFilters.Get("crashloop"))
+		}
+	}
+
 	err = psFilters.WalkValues("health", func(value string) error {
 		if !container.IsValidHealthString(value) {
 			return fmt.Errorf("Unrecognised filter value for health: %s", value)
@@ -320,6 +338,8 @@ func (daemon *Daemon) foldFilter(config *types.ContainerListOptions) (*listConte
 		sinceFilter:          sinceContFilter,
 		taskFilter:           taskFilter,
 		isTask:               isTask,
+		crashLoopFilter:      crashLoopFilter,
+		crashLooping:         crashLooping,
 		ContainerListOptions: config,
 		names:                daemon.nameIndex.GetAll(),
 	}, nil
@@ -365,6 +385,12 @@ func includeContainerInList(container *container.Container, ctx *listContext) it
 		}
 	}
 
+	if ctx.crashLoopFilter {
+		if ctx.crashLooping != container.CrashLooping {
+			return excludeContainer
+		}
+	}
+
 	// Do not include container if any of the labels don't match
 	if !ctx.filters.MatchKVList("label", container.Config.Labels) {
 		return excludeContainer
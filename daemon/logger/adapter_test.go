@@ -0,0 +1,261 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/plugins/logdriver"
+	"github.com/gogo/protobuf/proto"
+)
+
+// mockLoggingPlugin implements the logPlugin interface for testing purposes
+// it only supports a single log stream
+type mockLoggingPlugin struct {
+	inStream io.ReadCloser
+	f        *os.File
+	closed   chan struct{}
+}
+
+func (l *mockLoggingPlugin) StartLogging(file string, info Context) error {
+	go func() {
+		io.Copy(l.f, l.inStream)
+		close(l.closed)
+	}()
+	return nil
+}
+
+func (l *mockLoggingPlugin) StopLogging(file string) error {
+	l.inStream.Close()
+	l.f.Close()
+	os.Remove(l.f.Name())
+	return nil
+}
+
+func (l *mockLoggingPlugin) Capabilities() (cap Capability, err error) {
+	return Capability{ReadLogs: true}, nil
+}
+
+func (l *mockLoggingPlugin) ReadLogs(info Context, config ReadConfig) (io.ReadCloser, error) {
+	r, w := io.Pipe()
+	f, err := os.Open(l.f.Name())
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer f.Close()
+		dec := logdriver.NewLogEntryDecoder(f)
+		enc := logdriver.NewLogEntryEncoder(w)
+
+		for {
+			select {
+			case <-l.closed:
+				w.Close()
+				return
+			default:
+			}
+
+			var msg logdriver.LogEntry
+			if err := dec.Decode(&msg); err != nil {
+				if err == io.EOF {
+					if !config.Follow {
+						w.Close()
+						return
+					}
+					dec = logdriver.NewLogEntryDecoder(f)
+					continue
+				}
+
+				w.CloseWithError(err)
+				return
+			}
+
+			if err := enc.Encode(&msg); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return r, nil
+}
+
+func newMockPluginAdapter(t *testing.T) Logger {
+	r, w := io.Pipe()
+	f, err := ioutil.TempFile("", "mock-plugin-adapter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enc := logdriver.NewLogEntryEncoder(w)
+	a := &pluginAdapterWithRead{
+		&pluginAdapter{
+			plugin: &mockLoggingPlugin{
+				inStream: r,
+				f:        f,
+				closed:   make(chan struct{}),
+			},
+			stream: w,
+			enc:    enc,
+		},
+	}
+	a.plugin.StartLogging("", Context{})
+	return a
+}
+
+func TestAdapterReadLogs(t *testing.T) {
+	l := newMockPluginAdapter(t)
+
+	testMsg := []Message{
+		{Source: "stdout", Timestamp: time.Now(), Line: []byte("hello")},
+		{Source: "stdout", Timestamp: time.Now(), Line: []byte("world")},
+	}
+	for _, msg := range testMsg {
+		m := msg
+		if err := l.Log(&m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lr, ok := l.(LogReader)
+	if !ok {
+		t.Fatal("expected log reader")
+	}
+
+	lw := lr.ReadLogs(ReadConfig{})
+
+	for _, x := range testMsg {
+		select {
+		case msg := <-lw.Msg:
+			testMessageEqual(t, &x, msg)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout reading logs")
+		}
+	}
+
+	select {
+	case _, ok := <-lw.Msg:
+		if ok {
+			t.Fatal("expected message channel to be closed")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for message channel to close")
+
+	}
+	lw.Close()
+
+	lw = lr.ReadLogs(ReadConfig{Follow: true})
+	for _, x := range testMsg {
+		select {
+		case msg := <-lw.Msg:
+			testMessageEqual(t, &x, msg)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout reading logs")
+		}
+	}
+
+	x := Message{Source: "stdout", Timestamp: time.Now(), Line: []byte("follow test")}
+	if err := l.Log(&x); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg, open := <-lw.Msg:
+		if !open {
+			t.Fatal("expected message channel to remain open")
+		}
+		testMessageEqual(t, &x, msg)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout reading logs")
+	}
+
+	l.Close()
+	select {
+	case _, open := <-lw.Msg:
+		if open {
+			t.Fatal("expected message channel to be closed after closing logger")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for logger to close")
+	}
+}
+
+func TestAdapterLogWireFormat(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	a := &pluginAdapter{enc: logdriver.NewLogEntryEncoder(buf)}
+
+	ts := time.Unix(0, 1234)
+	if err := a.Log(&Message{Source: "stderr", Timestamp: ts, Line: []byte("line"), Partial: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	size := binary.BigEndian.Uint32(buf.Next(4))
+	if int(size) != buf.Len() {
+		t.Fatalf("expected message size %d, got %d", buf.Len(), size)
+	}
+	var entry logdriver.LogEntry
+	if err := proto.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Source != "stderr" || entry.TimeNano != 1234 || string(entry.Line) != "line" || !entry.Partial {
+		t.Fatalf("unexpected entry: %v", &entry)
+	}
+}
+
+// failingStopPlugin is a logging plugin that cannot stop logging
+type failingStopPlugin struct {
+	mockLoggingPlugin
+}
+
+func (l *failingStopPlugin) StopLogging(file string) error {
+	return errors.New("plugin is not responding")
+}
+
+func TestAdapterCloseCleansUpWhenStopLoggingFails(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugin-adapter-close")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fifoPath := filepath.Join(dir, "fifo")
+	if err := ioutil.WriteFile(fifoPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	r, w := io.Pipe()
+	a := &pluginAdapter{
+		plugin:   &failingStopPlugin{},
+		basePath: dir,
+		fifoPath: fifoPath,
+		stream:   w,
+		enc:      logdriver.NewLogEntryEncoder(w),
+	}
+
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fifoPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the fifo to be removed, got %v", err)
+	}
+	if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("expected the stream to be closed, got %v", err)
+	}
+}
+
+func testMessageEqual(t *testing.T, a, b *Message) {
+	if !bytes.Equal(a.Line, b.Line) {
+		t.Fatalf("%q != %q", a.Line, b.Line)
+	}
+	if a.Timestamp.UnixNano() != b.Timestamp.UnixNano() {
+		t.Fatalf("%d != %d", a.Timestamp.UnixNano(), b.Timestamp.UnixNano())
+	}
+	if a.Source != b.Source {
+		t.Fatalf("%q != %q", a.Source, b.Source)
+	}
+}
@@ -3,6 +3,11 @@ package logger
 import (
 	"fmt"
 	"sync"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	getter "github.com/docker/docker/pkg/plugingetter"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
 )
 
 // Creator builds a logging driver instance with given context.
@@ -33,6 +38,13 @@ func (lf *logdriverFactory) driverRegistered(name string) bool {
 	lf.m.Lock()
 	_, ok := lf.registry[name]
 	lf.m.Unlock()
+	if !ok {
+		if pluginGetter != nil { // this can be nil when the init functions are running
+			if l, _ := getPlugin(name, getter.LOOKUP); l != nil {
+				return true
+			}
+		}
+	}
 	return ok
 }
 
@@ -52,10 +64,12 @@ func (lf *logdriverFactory) get(name string) (Creator, error) {
 	defer lf.m.Unlock()
 
 	c, ok := lf.registry[name]
-	if !ok {
-		return c, fmt.Errorf("logger: no log driver named '%s' is registered", name)
+	if ok {
+		return c, nil
 	}
-	return c, nil
+
+	c, err := getPlugin(name, getter.ACQUIRE)
+	return c, errors.Wrapf(err, "logger: no log driver named '%s' is registered", name)
 }
 
 func (lf *logdriverFactory) getLogOptValidator(name string) LogOptValidator {
@@ -85,20 +99,75 @@ func GetLogDriver(name string) (Creator, error) {
 	return factory.get(name)
 }
 
+// builtInLogOpts are the options handled by the daemon for every log
+// driver, rather than by the driver itself.
+var builtInLogOpts = map[string]bool{
+	"mode":            true,
+	"max-buffer-size": true,
+}
+
+// externalValidators validate the options handled outside of the drivers.
+var externalValidators []LogOptValidator
+
+// AddBuiltinLogOpts adds options that are handled by the daemon for every log
+// driver, and are not passed to the validator of the driver. It must only be
+// called from init functions.
+func AddBuiltinLogOpts(opts map[string]bool) {
+	for k, v := range opts {
+		builtInLogOpts[k] = v
+	}
+}
+
+// RegisterExternalValidator adds a validator that ValidateLogOpts runs for
+// every log driver, to validate options added with AddBuiltinLogOpts. It must
+// only be called from init functions.
+func RegisterExternalValidator(v LogOptValidator) {
+	externalValidators = append(externalValidators, v)
+}
+
 // ValidateLogOpts checks the options for the given log driver. The
-// options supported are specific to the LogDriver implementation.
+// options supported are specific to the LogDriver implementation, except
+// for the built-in options, such as "mode" and "max-buffer-size".
 func ValidateLogOpts(name string, cfg map[string]string) error {
 	if name == "none" {
 		return nil
 	}
 
+	switch containertypes.LogMode(cfg["mode"]) {
+	case containertypes.LogModeBlocking, containertypes.LogModeNonBlock, containertypes.LogModeUnset:
+	default:
+		return fmt.Errorf("logger: logging mode not supported: %s", cfg["mode"])
+	}
+
+	if s, ok := cfg["max-buffer-size"]; ok {
+		if containertypes.LogMode(cfg["mode"]) != containertypes.LogModeNonBlock {
+			return fmt.Errorf("logger: max-buffer-size option is only supported with 'mode=%s'", containertypes.LogModeNonBlock)
+		}
+		if _, err := units.RAMInBytes(s); err != nil {
+			return errors.Wrap(err, "error parsing option max-buffer-size")
+		}
+	}
+
+	for _, validator := range externalValidators {
+		if err := validator(cfg); err != nil {
+			return err
+		}
+	}
+
 	if !factory.driverRegistered(name) {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
 
+	filteredOpts := make(map[string]string, len(cfg))
+	for k, v := range cfg {
+		if !builtInLogOpts[k] {
+			filteredOpts[k] = v
+		}
+	}
+
 	validator := factory.getLogOptValidator(name)
 	if validator != nil {
-		return validator(cfg)
+		return validator(filteredOpts)
 	}
 	return nil
 }
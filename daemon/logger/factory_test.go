@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterLogDriver("validate-test", func(Context) (Logger, error) { return nil, nil })
+	RegisterLogOptValidator("validate-test", func(cfg map[string]string) error {
+		for key := range cfg {
+			if key != "known" {
+				return fmt.Errorf("unknown log opt '%s'", key)
+			}
+		}
+		return nil
+	})
+}
+
+func TestValidateLogOptsMode(t *testing.T) {
+	valid := []map[string]string{
+		{},
+		{"known": "value"},
+		{"mode": "blocking"},
+		{"mode": "non-blocking"},
+		{"mode": "non-blocking", "max-buffer-size": "4m", "known": "value"},
+	}
+	for _, cfg := range valid {
+		if err := ValidateLogOpts("validate-test", cfg); err != nil {
+			t.Fatalf("expected %v to be valid, got %v", cfg, err)
+		}
+	}
+
+	invalid := map[string]map[string]string{
+		"logging mode not supported":  {"mode": "lossy"},
+		"only supported with":         {"max-buffer-size": "4m"},
+		"error parsing option":        {"mode": "non-blocking", "max-buffer-size": "lots"},
+		"unknown log opt 'something'": {"mode": "non-blocking", "something": "else"},
+	}
+	for expected, cfg := range invalid {
+		err := ValidateLogOpts("validate-test", cfg)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected error containing %q for %v, got %v", expected, cfg, err)
+		}
+	}
+}
@@ -41,6 +41,17 @@ func init() {
 // New creates new JSONFileLogger which writes to filename passed in
 // on given context.
 func New(ctx logger.Context) (logger.Logger, error) {
+	return newJSONFileLogger(ctx, false)
+}
+
+// NewReader creates a JSONFileLogger that only reads the logs written to the
+// filename passed in on given context by a JSONFileLogger with the same
+// options. The file is not created if it does not exist, and Log fails.
+func NewReader(ctx logger.Context) (logger.Logger, error) {
+	return newJSONFileLogger(ctx, true)
+}
+
+func newJSONFileLogger(ctx logger.Context, readOnly bool) (logger.Logger, error) {
 	var capval int64 = -1
 	if capacity, ok := ctx.Config["max-size"]; ok {
 		var err error
@@ -61,7 +72,25 @@ func New(ctx logger.Context) (logger.Logger, error) {
 		}
 	}
 
-	writer, err := loggerutils.NewRotateFileWriter(ctx.LogPath, capval, maxFiles)
+	var compress bool
+	if compressString, ok := ctx.Config["compress"]; ok {
+		var err error
+		compress, err = strconv.ParseBool(compressString)
+		if err != nil {
+			return nil, err
+		}
+		if compress && (maxFiles == 1 || capval == -1) {
+			return nil, fmt.Errorf("compress cannot be true when max-file is less than 2 or max-size is not set")
+		}
+	}
+
+	var writer *loggerutils.RotateFileWriter
+	var err error
+	if readOnly {
+		writer, err = loggerutils.NewRotateFileReader(ctx.LogPath, maxFiles)
+	} else {
+		writer, err = loggerutils.NewRotateFileWriter(ctx.LogPath, capval, maxFiles, compress)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -113,12 +142,14 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 	return err
 }
 
-// ValidateLogOpt looks for json specific log options max-file & max-size.
+// ValidateLogOpt looks for json specific log options max-file, max-size &
+// compress.
 func ValidateLogOpt(cfg map[string]string) error {
 	for key := range cfg {
 		switch key {
 		case "max-file":
 		case "max-size":
+		case "compress":
 		case "labels":
 		case "env":
 		default:
@@ -152,6 +152,93 @@ func TestJSONFileLoggerWithOpts(t *testing.T) {
 
 }
 
+func TestJSONFileLoggerCompressed(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	filename := filepath.Join(tmp, "container.log")
+	config := map[string]string{"max-file": "3", "max-size": "1k", "compress": "true"}
+	l, err := New(logger.Context{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	for i := 0; i < 40; i++ {
+		if err := l.Log(&logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	readLines := func(tail int) []string {
+		var lines []string
+		lw := l.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: tail})
+		for {
+			select {
+			case msg, ok := <-lw.Msg:
+				if !ok {
+					return lines
+				}
+				lines = append(lines, string(msg.Line))
+			case err := <-lw.Err:
+				t.Fatal(err)
+			}
+		}
+	}
+	expectedLines := func(from, to int) []string {
+		var lines []string
+		for i := from; i < to; i++ {
+			lines = append(lines, "line"+strconv.Itoa(i)+"\n")
+		}
+		return lines
+	}
+
+	if lines := readLines(-1); !reflect.DeepEqual(lines, expectedLines(0, 40)) {
+		t.Fatalf("Wrong log content: %q", lines)
+	}
+	if lines := readLines(20); !reflect.DeepEqual(lines, expectedLines(20, 40)) {
+		t.Fatalf("Wrong tailed log content: %q", lines)
+	}
+
+	// Wait for the rotated files to be compressed.
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filename + ".1.gz", filename + ".2.gz"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("Expected compressed log file %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{filename + ".1", filename + ".2"} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Fatalf("Expected uncompressed log file %s to be removed, got %v", name, err)
+		}
+	}
+}
+
+func TestJSONFileLoggerCompressInvalid(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	for _, config := range []map[string]string{
+		{"max-file": "1", "max-size": "1k", "compress": "true"},
+		{"max-file": "2", "compress": "true"},
+		{"max-file": "2", "max-size": "1k", "compress": "maybe"},
+	} {
+		if _, err := New(logger.Context{LogPath: filepath.Join(tmp, "container.log"), Config: config}); err == nil {
+			t.Fatalf("Expected an error for log opts %v", config)
+		}
+	}
+}
+
 func TestJSONFileLoggerWithLabelsEnv(t *testing.T) {
 	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
 	tmp, err := ioutil.TempDir("", "docker-logger-")
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"time"
@@ -14,8 +13,8 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
 	"github.com/docker/docker/pkg/filenotify"
-	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonlog"
 	"github.com/docker/docker/pkg/tailfile"
 )
@@ -52,41 +51,28 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	// This will block writes!!!
 	l.mu.Lock()
 
-	pth := l.writer.LogPath()
-	var files []io.ReadSeeker
-	for i := l.writer.MaxFiles(); i > 1; i-- {
-		f, err := os.Open(fmt.Sprintf("%s.%d", pth, i-1))
-		if err != nil {
-			if !os.IsNotExist(err) {
-				logWatcher.Err <- err
-				break
-			}
-			continue
-		}
-		defer f.Close()
-
-		files = append(files, f)
+	files, err := l.writer.OpenRotatedFiles()
+	if err != nil {
+		logWatcher.Err <- err
+		l.mu.Unlock()
+		return
 	}
 
-	latestFile, err := os.Open(pth)
+	latestFile, err := os.Open(l.writer.LogPath())
 	if err != nil {
 		logWatcher.Err <- err
+		closeRotatedFiles(files)
 		l.mu.Unlock()
 		return
 	}
 	defer latestFile.Close()
 
 	if config.Tail != 0 {
-		tailer := ioutils.MultiReadSeeker(append(files, latestFile)...)
-		tailFile(tailer, logWatcher, config.Tail, config.Since)
+		tailFiles(files, latestFile, logWatcher, config.Tail, config.Since)
 	}
 
 	// close all the rotated files
-	for _, f := range files {
-		if err := f.(io.Closer).Close(); err != nil {
-			logrus.WithField("logger", "json-file").Warnf("error closing tailed log file: %v", err)
-		}
-	}
+	closeRotatedFiles(files)
 
 	if !config.Follow {
 		if err := latestFile.Close(); err != nil {
@@ -113,17 +99,52 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.writer.NotifyRotateEvict(notifyRotate)
 }
 
-func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since time.Time) {
-	var rdr io.Reader
-	rdr = f
-	if tail > 0 {
-		ls, err := tailfile.TailFile(f, tail)
+func closeRotatedFiles(files []*loggerutils.RotatedFile) {
+	for _, f := range files {
+		if err := f.Close(); err != nil {
+			logrus.WithField("logger", "json-file").Warnf("error closing tailed log file: %v", err)
+		}
+	}
+}
+
+// tailFiles sends the last tail lines of the rotated files followed by the
+// latest file, or all of their lines if tail is negative. Compressed files
+// can not be seeked, so they are tailed by reading them through.
+func tailFiles(files []*loggerutils.RotatedFile, latestFile *os.File, logWatcher *logger.LogWatcher, tail int, since time.Time) {
+	if tail < 0 {
+		readers := make([]io.Reader, 0, len(files)+1)
+		for _, f := range files {
+			readers = append(readers, f)
+		}
+		readers = append(readers, latestFile)
+		decodeLogs(io.MultiReader(readers...), logWatcher, since)
+		return
+	}
+
+	ls, err := tailfile.TailFile(latestFile, tail)
+	if err != nil {
+		logWatcher.Err <- err
+		return
+	}
+	for i := len(files) - 1; i >= 0 && len(ls) < tail; i-- {
+		older, err := tailRotatedFile(files[i], tail-len(ls))
 		if err != nil {
 			logWatcher.Err <- err
 			return
 		}
-		rdr = bytes.NewBuffer(bytes.Join(ls, []byte("\n")))
+		ls = append(older, ls...)
+	}
+	decodeLogs(bytes.NewBuffer(bytes.Join(ls, []byte("\n"))), logWatcher, since)
+}
+
+func tailRotatedFile(f *loggerutils.RotatedFile, n int) ([][]byte, error) {
+	if rs, ok := f.ReadSeeker(); ok {
+		return tailfile.TailFile(rs, n)
 	}
+	return tailfile.TailReader(f, n)
+}
+
+func decodeLogs(rdr io.Reader, logWatcher *logger.LogWatcher, since time.Time) {
 	dec := json.NewDecoder(rdr)
 	l := &jsonlog.JSONLog{}
 	for {
@@ -0,0 +1,156 @@
+// Package cache provides a local cache of the logs of a container, so that
+// the logs can be read back with any logging driver.
+package cache
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	units "github.com/docker/go-units"
+)
+
+const (
+	// DisabledOpt is the log option that disables the local cache of a
+	// container.
+	DisabledOpt = "cache-disabled"
+
+	maxSizeOpt  = "cache-max-size"
+	maxFileOpt  = "cache-max-file"
+	compressOpt = "cache-compress"
+)
+
+// defaultOpts are the options of the json-file logger that stores the cache.
+var defaultOpts = map[string]string{
+	"max-size": "20m",
+	"max-file": "5",
+	"compress": "true",
+}
+
+// cacheOpts are the log options of the cache, supported by every driver.
+var cacheOpts = map[string]bool{
+	DisabledOpt: true,
+	maxSizeOpt:  true,
+	maxFileOpt:  true,
+	compressOpt: true,
+}
+
+func init() {
+	logger.AddBuiltinLogOpts(cacheOpts)
+	logger.RegisterExternalValidator(validateLogOpts)
+}
+
+// IsCacheOpt reports whether key is a log option of the local cache.
+func IsCacheOpt(key string) bool {
+	return cacheOpts[key]
+}
+
+func validateLogOpts(cfg map[string]string) error {
+	if s, ok := cfg[DisabledOpt]; ok {
+		if _, err := strconv.ParseBool(s); err != nil {
+			return fmt.Errorf("invalid value for log opt '%s': %s", DisabledOpt, s)
+		}
+	}
+	if s, ok := cfg[maxSizeOpt]; ok {
+		if _, err := units.FromHumanSize(s); err != nil {
+			return fmt.Errorf("invalid value for log opt '%s': %s", maxSizeOpt, s)
+		}
+	}
+	if s, ok := cfg[maxFileOpt]; ok {
+		if n, err := strconv.Atoi(s); err != nil || n < 1 {
+			return fmt.Errorf("invalid value for log opt '%s': %s", maxFileOpt, s)
+		}
+	}
+	if s, ok := cfg[compressOpt]; ok {
+		if _, err := strconv.ParseBool(s); err != nil {
+			return fmt.Errorf("invalid value for log opt '%s': %s", compressOpt, s)
+		}
+	}
+	return nil
+}
+
+// ShouldUseCache reports whether the local cache is enabled by the log
+// options of a container. It is enabled unless "cache-disabled" is true.
+func ShouldUseCache(cfg map[string]string) bool {
+	disabled, _ := strconv.ParseBool(cfg[DisabledOpt])
+	return !disabled
+}
+
+// WithLocalCache returns a Logger that sends the messages to l, and also
+// writes them to a local cache at ctx.LogPath, from which they can be read
+// back. The cache is rotated according to the "cache-max-size" and
+// "cache-max-file" options.
+func WithLocalCache(l logger.Logger, ctx logger.Context) (logger.Logger, error) {
+	cache, err := jsonfilelog.New(cacheContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &loggerWithCache{l: l, cache: cache}, nil
+}
+
+// NewReader returns a Logger that reads the local cache at ctx.LogPath,
+// written by a Logger returned by WithLocalCache with the same options. The
+// cache is opened read-only, and is not created if it does not exist.
+func NewReader(ctx logger.Context) (logger.Logger, error) {
+	return jsonfilelog.NewReader(cacheContext(ctx))
+}
+
+// cacheContext returns the context of the json-file logger that stores the
+// cache of a container with the given context.
+func cacheContext(ctx logger.Context) logger.Context {
+	cacheCtx := ctx
+	cacheCtx.Config = make(map[string]string)
+	for k, v := range defaultOpts {
+		cacheCtx.Config[k] = v
+	}
+	for opt, key := range map[string]string{maxSizeOpt: "max-size", maxFileOpt: "max-file", compressOpt: "compress"} {
+		if v, ok := ctx.Config[opt]; ok {
+			cacheCtx.Config[key] = v
+		}
+	}
+	if cacheCtx.Config["max-file"] == "1" {
+		// json-file cannot compress a single file
+		cacheCtx.Config["compress"] = "false"
+	}
+	// keep the extra attributes, for "docker logs --details"
+	for _, key := range []string{"labels", "env"} {
+		if v, ok := ctx.Config[key]; ok {
+			cacheCtx.Config[key] = v
+		}
+	}
+
+	return cacheCtx
+}
+
+// loggerWithCache is a Logger that writes every message to a local cache
+// before sending it to another Logger, and reads the logs from the cache.
+type loggerWithCache struct {
+	l     logger.Logger
+	cache logger.Logger
+}
+
+func (l *loggerWithCache) Log(msg *logger.Message) error {
+	// the message is still sent to the logging driver if the cache fails
+	if err := l.cache.Log(msg); err != nil {
+		logrus.Warnf("Failed to write log msg to the local cache for logger %s: %s", l.l.Name(), err)
+	}
+	return l.l.Log(msg)
+}
+
+func (l *loggerWithCache) Name() string {
+	return l.l.Name()
+}
+
+func (l *loggerWithCache) ReadLogs(cfg logger.ReadConfig) *logger.LogWatcher {
+	return l.cache.(logger.LogReader).ReadLogs(cfg)
+}
+
+func (l *loggerWithCache) Close() error {
+	err := l.l.Close()
+	if cacheErr := l.cache.Close(); err == nil {
+		err = cacheErr
+	}
+	return err
+}
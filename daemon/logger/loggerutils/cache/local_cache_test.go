@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+type countingLogger struct {
+	logged int
+	closed bool
+}
+
+func (l *countingLogger) Log(*logger.Message) error {
+	l.logged++
+	return nil
+}
+
+func (l *countingLogger) Name() string { return "counting" }
+
+func (l *countingLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+func TestWithLocalCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	driver := &countingLogger{}
+	l, err := WithLocalCache(driver, logger.Context{
+		ContainerID: "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657",
+		LogPath:     filepath.Join(dir, "container-cached.log"),
+		Config:      map[string]string{maxFileOpt: "2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"one", "two", "three"} {
+		if err := l.Log(&logger.Message{Line: []byte(line), Source: "stdout", Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if driver.logged != 3 {
+		t.Fatalf("expected 3 messages sent to the driver, got %d", driver.logged)
+	}
+
+	reader, ok := l.(logger.LogReader)
+	if !ok {
+		t.Fatal("expected the cached logger to support reading logs")
+	}
+	watcher := reader.ReadLogs(logger.ReadConfig{Tail: 2})
+	var lines []string
+	for msg := range watcher.Msg {
+		lines = append(lines, string(msg.Line))
+	}
+	if len(lines) != 2 || lines[0] != "two\n" || lines[1] != "three\n" {
+		t.Fatalf("unexpected lines read from the cache: %q", lines)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.closed {
+		t.Fatal("expected the driver to be closed")
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := logger.Context{
+		ContainerID: "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657",
+		LogPath:     filepath.Join(dir, "container-cached.log"),
+		Config:      map[string]string{maxFileOpt: "2"},
+	}
+
+	// the cache is not created when there is none
+	if _, err := NewReader(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error without a cache, got %v", err)
+	}
+	if _, err := os.Stat(ctx.LogPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the cache not to be created, got %v", err)
+	}
+
+	l, err := WithLocalCache(&countingLogger{}, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Log(&logger.Message{Line: []byte("one"), Source: "stdout", Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if err := r.Log(&logger.Message{Line: []byte("two"), Source: "stdout", Timestamp: time.Now()}); err == nil {
+		t.Fatal("expected writing to a read-only cache to fail")
+	}
+	watcher := r.(logger.LogReader).ReadLogs(logger.ReadConfig{Tail: -1})
+	var lines []string
+	for msg := range watcher.Msg {
+		lines = append(lines, string(msg.Line))
+	}
+	if len(lines) != 1 || lines[0] != "one\n" {
+		t.Fatalf("unexpected lines read from the cache: %q", lines)
+	}
+}
+
+func TestShouldUseCache(t *testing.T) {
+	if !ShouldUseCache(map[string]string{}) {
+		t.Fatal("expected the cache to be enabled by default")
+	}
+	if ShouldUseCache(map[string]string{DisabledOpt: "true"}) {
+		t.Fatal("expected the cache to be disabled")
+	}
+}
+
+func TestValidateLogOpts(t *testing.T) {
+	valid := map[string]string{
+		DisabledOpt: "false",
+		maxSizeOpt:  "10m",
+		maxFileOpt:  "3",
+		compressOpt: "false",
+	}
+	if err := validateLogOpts(valid); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, invalid := range []map[string]string{
+		{DisabledOpt: "maybe"},
+		{maxSizeOpt: "huge"},
+		{maxFileOpt: "0"},
+		{compressOpt: "zip"},
+	} {
+		if err := validateLogOpts(invalid); err == nil {
+			t.Fatalf("expected %v to be invalid", invalid)
+		}
+	}
+}
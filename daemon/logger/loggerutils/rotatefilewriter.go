@@ -1,25 +1,37 @@
 package loggerutils
 
 import (
+	"compress/gzip"
+	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"sync"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/pubsub"
 )
 
+// compressedExt is the extension added to rotated files once compressed.
+const compressedExt = ".gz"
+
 // RotateFileWriter is Logger implementation for default Docker logging.
 type RotateFileWriter struct {
 	f            *os.File // store for closing
 	mu           sync.Mutex
+	rotateMu     sync.RWMutex // held for writing while rotated files are renamed
+	compressMu   sync.Mutex   // serializes compression of rotated files
+	compressWg   sync.WaitGroup
 	capacity     int64 //maximum size of each file
 	currentSize  int64 // current size of the latest file
 	maxFiles     int   //maximum number of files
+	compress     bool  // whether rotated files are gzipped
 	notifyRotate *pubsub.Publisher
 }
 
-//NewRotateFileWriter creates new RotateFileWriter
-func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateFileWriter, error) {
+// NewRotateFileWriter creates new RotateFileWriter. If compress is set,
+// rotated files are gzipped in the background.
+func NewRotateFileWriter(logPath string, capacity int64, maxFiles int, compress bool) (*RotateFileWriter, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
 	if err != nil {
 		return nil, err
@@ -35,6 +47,24 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 		capacity:     capacity,
 		currentSize:  size,
 		maxFiles:     maxFiles,
+		compress:     compress,
+		notifyRotate: pubsub.NewPublisher(0, 1),
+	}, nil
+}
+
+// NewRotateFileReader creates a RotateFileWriter that only reads the logs
+// written to logPath by another RotateFileWriter with the same maxFiles. The
+// log file is not created, and writing to the returned writer fails.
+func NewRotateFileReader(logPath string, maxFiles int) (*RotateFileWriter, error) {
+	log, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RotateFileWriter{
+		f:            log,
+		capacity:     -1,
+		maxFiles:     maxFiles,
 		notifyRotate: pubsub.NewPublisher(0, 1),
 	}, nil
 }
@@ -65,32 +95,64 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 		if err := w.f.Close(); err != nil {
 			return err
 		}
+		// Wait for readers to be done opening the rotated files.
+		w.rotateMu.Lock()
 		if err := rotate(name, w.maxFiles); err != nil {
+			w.rotateMu.Unlock()
 			return err
 		}
 		file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 06400)
 		if err != nil {
+			w.rotateMu.Unlock()
 			return err
 		}
+		var rotated os.FileInfo
+		if w.compress && w.maxFiles > 1 {
+			rotated, err = os.Stat(name + ".1")
+			if err != nil {
+				logrus.WithField("file", name+".1").Errorf("failed to compress rotated log file: %v", err)
+			}
+		}
+		w.rotateMu.Unlock()
 		w.f = file
 		w.currentSize = 0
 		w.notifyRotate.Publish(struct{}{})
+
+		if rotated != nil {
+			w.compressWg.Add(1)
+			go func() {
+				w.compressRotated(name, rotated)
+				w.compressWg.Done()
+			}()
+		}
 	}
 
 	return nil
 }
 
+// rotate shifts the rotated files of the given log by one, dropping the
+// oldest one. Both compressed and uncompressed rotated files are shifted, as
+// a rotated file is left uncompressed if compressing it failed, or if it was
+// rotated before compression was enabled.
 func rotate(name string, maxFiles int) error {
 	if maxFiles < 2 {
 		return nil
 	}
-	for i := maxFiles - 1; i > 1; i-- {
-		toPath := name + "." + strconv.Itoa(i)
-		fromPath := name + "." + strconv.Itoa(i-1)
-		if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
+	for _, extension := range []string{"", compressedExt} {
+		oldest := name + "." + strconv.Itoa(maxFiles-1) + extension
+		if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
 			return err
 		}
 	}
+	for i := maxFiles - 1; i > 1; i-- {
+		for _, extension := range []string{"", compressedExt} {
+			toPath := name + "." + strconv.Itoa(i) + extension
+			fromPath := name + "." + strconv.Itoa(i-1) + extension
+			if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
 
 	if err := os.Rename(name, name+".1"); err != nil && !os.IsNotExist(err) {
 		return err
@@ -98,6 +160,137 @@ func rotate(name string, maxFiles int) error {
 	return nil
 }
 
+// compressRotated gzips the given rotated file of the given log.
+// Compression happens without holding rotateMu, so that neither readers nor
+// further rotations are blocked by it. As the file may be rotated again in
+// the meantime, it is looked up again once compression is done, and the
+// compressed file is moved in its place. The uncompressed file is kept if
+// compression fails, and picked up by readers as is.
+func (w *RotateFileWriter) compressRotated(name string, rotated os.FileInfo) {
+	w.compressMu.Lock()
+	defer w.compressMu.Unlock()
+
+	tmpName := name + compressedExt + ".tmp"
+
+	w.rotateMu.RLock()
+	fileName := w.findRotated(name, rotated)
+	if fileName == "" {
+		// The file was rotated out before being compressed.
+		w.rotateMu.RUnlock()
+		return
+	}
+	file, err := os.Open(fileName)
+	w.rotateMu.RUnlock()
+	if err != nil {
+		logrus.WithField("file", fileName).Errorf("failed to compress rotated log file: %v", err)
+		return
+	}
+
+	err = gzipFile(file, tmpName)
+	file.Close()
+	if err != nil {
+		logrus.WithField("file", fileName).Errorf("failed to compress rotated log file: %v", err)
+		os.Remove(tmpName)
+		return
+	}
+
+	w.rotateMu.Lock()
+	defer w.rotateMu.Unlock()
+
+	fileName = w.findRotated(name, rotated)
+	if fileName == "" {
+		os.Remove(tmpName)
+		return
+	}
+	if err := os.Rename(tmpName, fileName+compressedExt); err != nil {
+		logrus.WithField("file", fileName).Errorf("failed to compress rotated log file: %v", err)
+		os.Remove(tmpName)
+		return
+	}
+	if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+		logrus.WithField("file", fileName).Errorf("failed to remove compressed log file: %v", err)
+	}
+}
+
+// findRotated returns the current path of the given uncompressed rotated
+// file, or an empty string if it no longer exists. It must be called with
+// rotateMu held.
+func (w *RotateFileWriter) findRotated(name string, rotated os.FileInfo) string {
+	for i := 1; i < w.maxFiles; i++ {
+		fileName := name + "." + strconv.Itoa(i)
+		if fi, err := os.Stat(fileName); err == nil && os.SameFile(rotated, fi) {
+			return fileName
+		}
+	}
+	return ""
+}
+
+func gzipFile(file *os.File, outName string) error {
+	outFile, err := os.OpenFile(outName, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0640)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	compressWriter := gzip.NewWriter(outFile)
+	if _, err := io.Copy(compressWriter, file); err != nil {
+		return err
+	}
+	return compressWriter.Close()
+}
+
+// OpenRotatedFiles opens the rotated log files, oldest first, decompressing
+// them as needed. Files that do not exist are skipped. It must be called
+// with the writer's lock held, so that no rotation happens while the files
+// are being opened.
+// The caller is responsible for closing the returned files.
+func (w *RotateFileWriter) OpenRotatedFiles() (files []*RotatedFile, err error) {
+	w.rotateMu.RLock()
+	defer w.rotateMu.RUnlock()
+
+	defer func() {
+		if err != nil {
+			for _, f := range files {
+				f.Close()
+			}
+			files = nil
+		}
+	}()
+
+	name := w.f.Name()
+	for i := w.maxFiles - 1; i >= 1; i-- {
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", name, i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return files, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func openRotatedFile(fileName string) (*RotatedFile, error) {
+	f, err := os.Open(fileName + compressedExt)
+	if err == nil {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &RotatedFile{f: f, gz: gz}, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	f, err = os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &RotatedFile{f: f}, nil
+}
+
 // LogPath returns the location the given writer logs to.
 func (w *RotateFileWriter) LogPath() string {
 	return w.f.Name()
@@ -118,7 +311,41 @@ func (w *RotateFileWriter) NotifyRotateEvict(sub chan interface{}) {
 	w.notifyRotate.Evict(sub)
 }
 
-// Close closes underlying file and signals all readers to stop.
+// Close closes underlying file and signals all readers to stop. Pending
+// compression of rotated files is waited for.
 func (w *RotateFileWriter) Close() error {
-	return w.f.Close()
+	err := w.f.Close()
+	w.compressWg.Wait()
+	return err
+}
+
+// RotatedFile is a rotated log file opened for reading. Compressed files are
+// decompressed while being read.
+type RotatedFile struct {
+	f  *os.File
+	gz *gzip.Reader
+}
+
+func (r *RotatedFile) Read(p []byte) (int, error) {
+	if r.gz != nil {
+		return r.gz.Read(p)
+	}
+	return r.f.Read(p)
+}
+
+// ReadSeeker returns the underlying file if it is not compressed. Compressed
+// files can only be read sequentially.
+func (r *RotatedFile) ReadSeeker() (io.ReadSeeker, bool) {
+	if r.gz != nil {
+		return nil, false
+	}
+	return r.f, true
+}
+
+// Close closes the file.
+func (r *RotatedFile) Close() error {
+	if r.gz != nil {
+		r.gz.Close()
+	}
+	return r.f.Close()
 }
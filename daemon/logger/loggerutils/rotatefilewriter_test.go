@@ -0,0 +1,60 @@
+package loggerutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotateMixedCompression(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-rotate-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "container.log")
+
+	// container.log.1 failed to be compressed, container.log.2 was rotated
+	// before compression was enabled.
+	files := map[string]string{
+		name:           "current",
+		name + ".1":    "one",
+		name + ".2":    "two",
+		name + ".2.gz": "stale",
+	}
+	for fileName, content := range files {
+		if err := ioutil.WriteFile(fileName, []byte(content), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := rotate(name, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		name + ".1": "current",
+		name + ".2": "one",
+	}
+	for fileName, content := range expected {
+		b, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Fatalf("Expected %s to contain %q, got %q", fileName, content, b)
+		}
+	}
+	entries, err := ioutil.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(expected) {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Fatalf("Expected %d rotated files, got %v", len(expected), names)
+	}
+}
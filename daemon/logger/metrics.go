@@ -0,0 +1,11 @@
+package logger
+
+import "github.com/docker/go-metrics"
+
+var droppedMessages metrics.LabeledCounter
+
+func init() {
+	ns := metrics.NewNamespace("engine", "daemon", nil)
+	droppedMessages = ns.NewLabeledCounter("log_messages_dropped", "The number of log messages dropped by non-blocking loggers because their buffer was full", "driver")
+	metrics.Register(ns)
+}
@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// DefaultRingMaxSize is the maximum number of bytes of log messages that a
+// RingLogger buffers when no max-buffer-size is set.
+const DefaultRingMaxSize = 1024 * 1024
+
+var errRingClosed = errors.New("logger: ring buffer is closed")
+
+// RingLogger is a Logger that buffers messages in memory, and delivers them
+// to another Logger in the background, so that a slow or stalled logging
+// driver does not block the container. When the buffer is full, the oldest
+// messages are dropped.
+type RingLogger struct {
+	buffer *messageRing
+	l      Logger
+	done   chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// ringWithReader is a RingLogger for a Logger that supports reading logs.
+type ringWithReader struct {
+	*RingLogger
+}
+
+// ReadLogs reads the logs from the wrapped Logger.
+func (r *ringWithReader) ReadLogs(cfg ReadConfig) *LogWatcher {
+	return r.l.(LogReader).ReadLogs(cfg)
+}
+
+// NewRingLogger returns a Logger that buffers up to maxSize bytes of messages
+// for driver. DefaultRingMaxSize is used if maxSize is not positive.
+func NewRingLogger(driver Logger, maxSize int64) Logger {
+	if maxSize <= 0 {
+		maxSize = DefaultRingMaxSize
+	}
+	l := &RingLogger{
+		buffer: newRing(maxSize),
+		l:      driver,
+		done:   make(chan struct{}),
+	}
+	go l.run()
+
+	if _, ok := driver.(LogReader); ok {
+		return &ringWithReader{l}
+	}
+	return l
+}
+
+// Log queues a copy of msg to be delivered to the wrapped Logger. It never
+// blocks on the wrapped Logger.
+func (r *RingLogger) Log(msg *Message) error {
+	dropped, err := r.buffer.Enqueue(CopyMessage(msg))
+	if dropped > 0 {
+		droppedMessages.WithValues(r.l.Name()).Inc(float64(dropped))
+	}
+	return err
+}
+
+// Name returns the name of the wrapped Logger.
+func (r *RingLogger) Name() string {
+	return r.l.Name()
+}
+
+// Close stops the delivery in the background, sends the messages that are
+// still buffered to the wrapped Logger, and closes it.
+func (r *RingLogger) Close() error {
+	r.closeOnce.Do(func() {
+		r.buffer.Close()
+		<-r.done
+
+		for _, msg := range r.buffer.Drain() {
+			if err := r.l.Log(msg); err != nil {
+				logrus.Errorf("Failed to flush log msg %q for logger %s: %s", msg.Line, r.l.Name(), err)
+			}
+		}
+		r.closeErr = r.l.Close()
+	})
+	return r.closeErr
+}
+
+// run delivers the buffered messages to the wrapped Logger until the
+// buffer is closed.
+func (r *RingLogger) run() {
+	defer close(r.done)
+	for {
+		msg, err := r.buffer.Dequeue()
+		if err != nil {
+			return
+		}
+		if err := r.l.Log(msg); err != nil {
+			logrus.Errorf("Failed to log msg %q for logger %s: %s", msg.Line, r.l.Name(), err)
+		}
+	}
+}
+
+// messageRing is a queue of messages bounded by the total size of their
+// lines.
+type messageRing struct {
+	mu   sync.Mutex
+	wait *sync.Cond
+
+	queue     []*Message
+	sizeBytes int64
+	maxBytes  int64
+	closed    bool
+}
+
+func newRing(maxBytes int64) *messageRing {
+	r := &messageRing{maxBytes: maxBytes}
+	r.wait = sync.NewCond(&r.mu)
+	return r
+}
+
+// Enqueue adds msg to the queue, dropping the oldest messages to make room
+// for it, and returns how many were dropped. The newest message is always
+// kept, even if it is larger than the queue.
+func (r *messageRing) Enqueue(msg *Message) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, errRingClosed
+	}
+
+	r.queue = append(r.queue, msg)
+	r.sizeBytes += int64(len(msg.Line))
+
+	dropped := 0
+	for r.sizeBytes > r.maxBytes && len(r.queue) > 1 {
+		r.sizeBytes -= int64(len(r.queue[0].Line))
+		r.queue[0] = nil
+		r.queue = r.queue[1:]
+		dropped++
+	}
+
+	r.wait.Signal()
+	return dropped, nil
+}
+
+// Dequeue removes the oldest message from the queue, waiting for one if the
+// queue is empty. It returns an error once the queue is closed.
+func (r *messageRing) Dequeue() (*Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for len(r.queue) == 0 && !r.closed {
+		r.wait.Wait()
+	}
+	if r.closed {
+		return nil, errRingClosed
+	}
+
+	msg := r.queue[0]
+	r.queue[0] = nil
+	r.queue = r.queue[1:]
+	r.sizeBytes -= int64(len(msg.Line))
+	return msg, nil
+}
+
+// Close closes the queue, and wakes up any waiting Dequeue.
+func (r *messageRing) Close() {
+	r.mu.Lock()
+	r.closed = true
+	r.wait.Broadcast()
+	r.mu.Unlock()
+}
+
+// Drain removes and returns all the messages left in the queue.
+func (r *messageRing) Drain() []*Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := r.queue
+	r.queue = nil
+	r.sizeBytes = 0
+	return msgs
+}
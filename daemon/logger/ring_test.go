@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type blockingLogger struct {
+	mu      sync.Mutex
+	unblock chan struct{}
+	logged  []string
+	closed  bool
+}
+
+func (l *blockingLogger) Log(m *Message) error {
+	<-l.unblock
+	l.mu.Lock()
+	l.logged = append(l.logged, string(m.Line))
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *blockingLogger) Name() string { return "blocking" }
+
+func (l *blockingLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+func (l *blockingLogger) lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.logged...)
+}
+
+func TestRingLoggerDoesNotBlock(t *testing.T) {
+	driver := &blockingLogger{unblock: make(chan struct{})}
+	l := NewRingLogger(driver, 1024)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			l.Log(&Message{Line: []byte(fmt.Sprintf("line %d", i))})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Log blocked on a stalled driver")
+	}
+
+	close(driver.unblock)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.closed {
+		t.Fatal("expected the wrapped logger to be closed")
+	}
+
+	lines := driver.lines()
+	if len(lines) == 0 || len(lines) == 1000 {
+		t.Fatalf("expected some messages to be dropped, got %d", len(lines))
+	}
+	if last := lines[len(lines)-1]; last != "line 999" {
+		t.Fatalf("expected the newest message to be kept, got %q", last)
+	}
+}
+
+func TestRingLoggerFlushesOnClose(t *testing.T) {
+	driver := &blockingLogger{unblock: make(chan struct{})}
+	l := NewRingLogger(driver, DefaultRingMaxSize)
+
+	msg := &Message{Line: []byte("line")}
+	for i := 0; i < 10; i++ {
+		msg.Line = []byte(fmt.Sprintf("line %d", i))
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(driver.unblock)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := driver.lines()
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 messages, got %d: %v", len(lines), lines)
+	}
+	for i, line := range lines {
+		if expected := fmt.Sprintf("line %d", i); line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+}
+
+func TestMessageRingDropsOldest(t *testing.T) {
+	r := newRing(10)
+	for _, line := range []string{"aaaa", "bbbb", "cccc"} {
+		dropped, err := r.Enqueue(&Message{Line: []byte(line)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "cccc" && dropped != 1 {
+			t.Fatalf("expected 1 dropped message, got %d", dropped)
+		}
+	}
+
+	msg, err := r.Dequeue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg.Line) != "bbbb" {
+		t.Fatalf("expected the oldest message to be dropped, got %q", msg.Line)
+	}
+
+	r.Close()
+	if _, err := r.Enqueue(&Message{Line: []byte("dddd")}); err != errRingClosed {
+		t.Fatalf("expected errRingClosed, got %v", err)
+	}
+	if _, err := r.Dequeue(); err != errRingClosed {
+		t.Fatalf("expected errRingClosed, got %v", err)
+	}
+	if msgs := r.Drain(); len(msgs) != 1 || string(msgs[0].Line) != "cccc" {
+		t.Fatalf("expected the remaining message to be drained, got %v", msgs)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/stdcopy"
 )
@@ -120,24 +121,39 @@ func (daemon *Daemon) getLogger(container *container.Container) (logger.Logger,
 	if container.LogDriver != nil && container.IsRunning() {
 		return container.LogDriver, nil
 	}
+	// read the local cache, if any, without starting the logging driver
+	if l, err := container.OpenLogCache(); err != nil || l != nil {
+		return l, err
+	}
 	return container.StartLogger()
 }
 
+// getDefaultLogConfig returns the default log config of the daemon, which
+// Reload can replace at any time.
+func (daemon *Daemon) getDefaultLogConfig() containertypes.LogConfig {
+	daemon.configStore.reloadLock.Lock()
+	defer daemon.configStore.reloadLock.Unlock()
+	return daemon.defaultLogConfig
+}
+
 // mergeLogConfig merges the daemon log config to the container's log config if the container's log driver is not specified.
 func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) error {
+	defaultLogConfig := daemon.getDefaultLogConfig()
 	if cfg.Type == "" {
-		cfg.Type = daemon.defaultLogConfig.Type
+		cfg.Type = defaultLogConfig.Type
 	}
 
 	if cfg.Config == nil {
 		cfg.Config = make(map[string]string)
 	}
 
-	if cfg.Type == daemon.defaultLogConfig.Type {
-		for k, v := range daemon.defaultLogConfig.Config {
-			if _, ok := cfg.Config[k]; !ok {
-				cfg.Config[k] = v
-			}
+	for k, v := range defaultLogConfig.Config {
+		// the options of the local cache apply to every log driver
+		if cfg.Type != defaultLogConfig.Type && !cache.IsCacheOpt(k) {
+			continue
+		}
+		if _, ok := cfg.Config[k]; !ok {
+			cfg.Config[k] = v
 		}
 	}
 
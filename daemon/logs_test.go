@@ -7,9 +7,23 @@ import (
 )
 
 func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
-	d := &Daemon{defaultLogConfig: containertypes.LogConfig{Type: "json-file", Config: map[string]string{"max-file": "1"}}}
+	d := &Daemon{configStore: &Config{}, defaultLogConfig: containertypes.LogConfig{Type: "json-file", Config: map[string]string{"max-file": "1"}}}
 	cfg := containertypes.LogConfig{Type: d.defaultLogConfig.Type}
 	if err := d.mergeAndVerifyLogConfig(&cfg); err != nil {
 		t.Fatal(err)
 	}
 }
+
+func TestMergeAndVerifyLogConfigCacheOpts(t *testing.T) {
+	d := &Daemon{configStore: &Config{}, defaultLogConfig: containertypes.LogConfig{Type: "json-file", Config: map[string]string{"max-file": "2", "cache-disabled": "true"}}}
+	cfg := containertypes.LogConfig{Type: "syslog"}
+	if err := d.mergeAndVerifyLogConfig(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Config["cache-disabled"] != "true" {
+		t.Fatalf("expected the cache options of the daemon to apply to every driver, got %v", cfg.Config)
+	}
+	if _, ok := cfg.Config["max-file"]; ok {
+		t.Fatalf("expected the options of the default driver not to apply to other drivers, got %v", cfg.Config)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/restartmanager"
 )
@@ -43,8 +44,12 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		c.Reset(false)
 
 		restart, wait, err := c.RestartManager().ShouldRestart(e.ExitCode, false, time.Since(c.StartedAt))
+		var enteredCrashLoop bool
 		if err == nil && restart {
 			c.RestartCount++
+			crashLooping := c.RestartManager().CrashLooping()
+			enteredCrashLoop = crashLooping && !c.CrashLooping
+			c.CrashLooping = crashLooping
 			c.SetRestarting(platformConstructExitStatus(e))
 		} else {
 			c.SetStopped(platformConstructExitStatus(e))
@@ -56,6 +61,11 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 			"exitCode": strconv.Itoa(int(e.ExitCode)),
 		}
 		daemon.LogContainerEventWithAttributes(c, "die", attributes)
+		if enteredCrashLoop {
+			daemon.LogContainerEventWithAttributes(c, "crashloop", map[string]string{
+				"restartCount": strconv.Itoa(c.RestartCount),
+			})
+		}
 		daemon.Cleanup(c)
 
 		if err == nil && restart {
@@ -110,6 +120,9 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		}
 		daemon.initHealthMonitor(c)
 		daemon.LogContainerEvent(c, "start")
+		if c.CrashLooping {
+			daemon.watchCrashLoopEnd(c)
+		}
 	case libcontainerd.StatePause:
 		// Container is already locked in this case
 		c.Paused = true
@@ -130,3 +143,26 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 
 	return nil
 }
+
+// watchCrashLoopEnd takes a container in a crash loop out of it once it has
+// been running for longer than the reset window of its restart policy, as a
+// crash after that would not count as part of the loop anymore. It must be
+// called with the container locked.
+func (daemon *Daemon) watchCrashLoopEnd(c *container.Container) {
+	startedAt := c.StartedAt
+	delay := c.RestartManager().ResetWindow() - time.Since(startedAt)
+	time.AfterFunc(delay, func() {
+		c.Lock()
+		// the container may have exited, or been restarted, in the meantime
+		if !c.CrashLooping || !c.Running || c.Restarting || !c.StartedAt.Equal(startedAt) {
+			c.Unlock()
+			return
+		}
+		c.CrashLooping = false
+		if err := c.ToDisk(); err != nil {
+			logrus.Errorf("Error saving the state of container %s: %v", c.ID, err)
+		}
+		daemon.LogContainerEvent(c, "crashloop_end")
+		c.Unlock()
+	})
+}
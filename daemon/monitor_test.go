@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/events"
+)
+
+func TestCrashLoopEnd(t *testing.T) {
+	root, err := ioutil.TempDir("", "crashloop-end")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	e := events.New()
+	_, l, _ := e.Subscribe()
+	defer e.Evict(l)
+	daemon := &Daemon{EventsService: e}
+
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:     "container_id",
+			Name:   "container_name",
+			Root:   root,
+			Config: &containertypes.Config{Image: "image_name"},
+			HostConfig: &containertypes.HostConfig{
+				RestartPolicy: containertypes.RestartPolicy{
+					Name:               "always",
+					ResetWindow:        10 * time.Millisecond,
+					CrashLoopThreshold: 1,
+				},
+			},
+			State: container.NewState(),
+		},
+	}
+	c.Lock()
+	c.SetRunning(1234, true)
+	c.CrashLooping = true
+	daemon.watchCrashLoopEnd(c)
+	c.Unlock()
+
+	select {
+	case ev := <-l:
+		if action := ev.(eventtypes.Message).Action; action != "crashloop_end" {
+			t.Fatalf("Expected a crashloop_end event, got %s", action)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected the container to leave the crash loop")
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.CrashLooping {
+		t.Fatal("Expected the container not to be crash looping anymore")
+	}
+}
+
+func TestCrashLoopEndAfterExit(t *testing.T) {
+	daemon := &Daemon{EventsService: events.New()}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:     "container_id",
+			Config: &containertypes.Config{},
+			HostConfig: &containertypes.HostConfig{
+				RestartPolicy: containertypes.RestartPolicy{
+					Name:        "always",
+					ResetWindow: 10 * time.Millisecond,
+				},
+			},
+			State: container.NewState(),
+		},
+	}
+	c.Lock()
+	c.SetRunning(1234, true)
+	c.CrashLooping = true
+	daemon.watchCrashLoopEnd(c)
+	// the container crashes again before the reset window is over
+	c.SetRestarting(&container.ExitStatus{ExitCode: 1})
+	c.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+	c.Lock()
+	defer c.Unlock()
+	if !c.CrashLooping {
+		t.Fatal("Expected the container to still be crash looping")
+	}
+}
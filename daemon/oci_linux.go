@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -106,6 +107,12 @@ func setDevices(s *specs.Spec, c *container.Container) error {
 			devs = append(devs, d...)
 			devPermissions = append(devPermissions, dPermissions...)
 		}
+
+		rules, err := parseDeviceCgroupRules(c.HostConfig.DeviceCgroupRules)
+		if err != nil {
+			return err
+		}
+		devPermissions = append(devPermissions, rules...)
 	}
 
 	s.Linux.Devices = append(s.Linux.Devices, devs...)
@@ -113,6 +120,42 @@ func setDevices(s *specs.Spec, c *container.Container) error {
 	return nil
 }
 
+var deviceCgroupRuleRegex = regexp.MustCompile(`^([acb]) ([0-9]+|\*):([0-9]+|\*) ([rwm]{1,3})$`)
+
+// parseDeviceCgroupRules converts rules of the form 'type major:minor mode'
+// into device cgroup permissions. A '*' major or minor number matches any.
+func parseDeviceCgroupRules(rules []string) ([]specs.DeviceCgroup, error) {
+	var devPermissions []specs.DeviceCgroup
+	for _, rule := range rules {
+		ss := deviceCgroupRuleRegex.FindStringSubmatch(rule)
+		if len(ss) != 5 {
+			return nil, fmt.Errorf("invalid device cgroup rule format: '%s'", rule)
+		}
+
+		dPermissions := specs.DeviceCgroup{
+			Allow:  true,
+			Type:   &ss[1],
+			Access: &ss[4],
+		}
+		if ss[2] != "*" {
+			major, err := strconv.ParseInt(ss[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid major value in device cgroup rule '%s': %v", rule, err)
+			}
+			dPermissions.Major = &major
+		}
+		if ss[3] != "*" {
+			minor, err := strconv.ParseInt(ss[3], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid minor value in device cgroup rule '%s': %v", rule, err)
+			}
+			dPermissions.Minor = &minor
+		}
+		devPermissions = append(devPermissions, dPermissions)
+	}
+	return devPermissions, nil
+}
+
 func setRlimits(daemon *Daemon, s *specs.Spec, c *container.Container) error {
 	var rlimits []specs.Rlimit
 
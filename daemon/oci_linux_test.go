@@ -0,0 +1,31 @@
+package daemon
+
+import (
+	"testing"
+)
+
+func TestParseDeviceCgroupRules(t *testing.T) {
+	rules := []string{"c 188:* rmw", "b 8:0 r", "a *:* m"}
+	expected := []string{"c 188:* rmw", "b 8:0 r", "a *:* m"}
+
+	devPermissions, err := parseDeviceCgroupRules(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devPermissions) != len(expected) {
+		t.Fatalf("Expected %d device permissions, got %d", len(expected), len(devPermissions))
+	}
+	for i, d := range devPermissions {
+		if !d.Allow {
+			t.Fatalf("Expected rule %q to allow access", rules[i])
+		}
+		if s := deviceCgroupString(d); s != expected[i] {
+			t.Fatalf("Expected %q, got %q", expected[i], s)
+		}
+	}
+
+	devPermissions, err = parseDeviceCgroupRules([]string{"c 188:1"})
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid rule, got %v", devPermissions)
+	}
+}
@@ -1,9 +1,13 @@
 package daemon
 
 import (
+	"fmt"
+
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/backend"
 	swarmtypes "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/swarmkit/agent/exec"
+	swarmapi "github.com/docker/swarmkit/api"
 )
 
 // SetContainerSecretStore sets the secret store backend for the container
@@ -34,3 +38,51 @@ func (daemon *Daemon) SetContainerSecretReferences(name string, refs []*swarmtyp
 
 	return nil
 }
+
+// ContainerSetSecretsOnBuild sets up the secrets to be mounted into a build
+// container for the duration of a single build step.
+func (daemon *Daemon) ContainerSetSecretsOnBuild(name string, secrets []backend.BuildSecret) error {
+	if len(secrets) == 0 {
+		return nil
+	}
+	if !secretsSupported() {
+		return fmt.Errorf("build secrets are not supported on this platform")
+	}
+
+	c, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	store := buildSecretStore{}
+	refs := make([]*swarmtypes.SecretReference, 0, len(secrets))
+	for _, s := range secrets {
+		store[s.ID] = &swarmapi.Secret{
+			ID:   s.ID,
+			Spec: swarmapi.SecretSpec{Data: s.Data},
+		}
+		refs = append(refs, &swarmtypes.SecretReference{
+			SecretID:   s.ID,
+			SecretName: s.ID,
+			File: &swarmtypes.SecretReferenceFileTarget{
+				Name: s.Target,
+				UID:  s.UID,
+				GID:  s.GID,
+				Mode: s.Mode,
+			},
+		})
+	}
+
+	c.SecretStore = store
+	c.SecretReferences = refs
+
+	return nil
+}
+
+// buildSecretStore is an in-memory exec.SecretGetter holding the secrets
+// passed to a build.
+type buildSecretStore map[string]*swarmapi.Secret
+
+func (s buildSecretStore) Get(secretID string) *swarmapi.Secret {
+	return s[secretID]
+}
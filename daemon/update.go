@@ -68,7 +68,7 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 
 	// if Restart Policy changed, we need to update container monitor
 	if hostConfig.RestartPolicy.Name != "" {
-		container.UpdateMonitor(hostConfig.RestartPolicy)
+		container.UpdateMonitor(container.HostConfig.RestartPolicy)
 	}
 
 	// If container is not running, update hostConfig struct is enough,
@@ -80,6 +80,12 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 			restoreConfig = true
 			return errCannotUpdate(container.ID, err)
 		}
+		if hostConfig.DeviceCgroupRules != nil {
+			if err := updateDeviceCgroup(container, backupHostConfig.DeviceCgroupRules); err != nil {
+				restoreConfig = true
+				return errCannotUpdate(container.ID, err)
+			}
+		}
 	}
 
 	daemon.LogContainerEvent(container, "update")
@@ -3,11 +3,24 @@
 package daemon
 
 import (
-	"github.com/docker/docker/api/types/container"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
 	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/oci"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
-func toContainerdResources(resources container.Resources) libcontainerd.Resources {
+func toContainerdResources(resources containertypes.Resources) libcontainerd.Resources {
 	var r libcontainerd.Resources
 	r.BlkioWeight = uint64(resources.BlkioWeight)
 	r.CpuShares = uint64(resources.CPUShares)
@@ -23,3 +36,213 @@ func toContainerdResources(resources container.Resources) libcontainerd.Resource
 	r.KernelMemoryLimit = uint64(resources.KernelMemory)
 	return r
 }
+
+// updateDeviceCgroup applies the changes between the old device cgroup rules
+// of a running container and its current ones. containerd does not support
+// updating device permissions, so the rules are written to the cgroup
+// directly. Only the access granted by the old rules and not by the new ones
+// is revoked, unless the same device is also allowed by the defaults of the
+// runtime or by a device of the container, so that the rules docker did not
+// add are never touched. If a rule cannot be written, the rules that were
+// already applied are reverted.
+func updateDeviceCgroup(c *container.Container, oldRules []string) error {
+	if c.HostConfig.Privileged {
+		return nil
+	}
+
+	old, err := parseDeviceCgroupRules(oldRules)
+	if err != nil {
+		return err
+	}
+	rules, err := parseDeviceCgroupRules(c.HostConfig.DeviceCgroupRules)
+	if err != nil {
+		return err
+	}
+	keep, err := defaultDeviceCgroups(c)
+	if err != nil {
+		return err
+	}
+
+	dir, err := deviceCgroupPath(c.Pid)
+	if err != nil {
+		return err
+	}
+
+	var applied []deviceCgroupChange
+	for _, change := range deviceCgroupChanges(old, rules, keep) {
+		if err := change.apply(dir); err != nil {
+			for i := len(applied) - 1; i >= 0; i-- {
+				if err := applied[i].revert().apply(dir); err != nil {
+					logrus.Errorf("Failed to revert device cgroup rule of container %s: %v", c.ID, err)
+				}
+			}
+			return fmt.Errorf("failed to write device cgroup rule: %v", err)
+		}
+		applied = append(applied, change)
+	}
+	return nil
+}
+
+// defaultDeviceCgroups returns the devices allowed to a container besides
+// its device cgroup rules: the defaults of docker and of the runtime, and
+// the devices added to the container.
+func defaultDeviceCgroups(c *container.Container) ([]specs.DeviceCgroup, error) {
+	devices := oci.DefaultSpec().Linux.Resources.Devices
+	for _, d := range configs.DefaultAllowedDevices {
+		devices = append(devices, runtimeDeviceCgroup(d))
+	}
+	for _, deviceMapping := range c.HostConfig.Devices {
+		_, dPermissions, err := oci.DevicesFromPath(deviceMapping.PathOnHost, deviceMapping.PathInContainer, deviceMapping.CgroupPermissions)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, dPermissions...)
+	}
+	return devices, nil
+}
+
+// runtimeDeviceCgroup converts a device allowed by the runtime to a device
+// cgroup permission.
+func runtimeDeviceCgroup(d *configs.Device) specs.DeviceCgroup {
+	typ, access := string(d.Type), d.Permissions
+	p := specs.DeviceCgroup{Allow: true, Type: &typ, Access: &access}
+	if d.Major != configs.Wildcard {
+		major := d.Major
+		p.Major = &major
+	}
+	if d.Minor != configs.Wildcard {
+		minor := d.Minor
+		p.Minor = &minor
+	}
+	return p
+}
+
+// deviceCgroupChange is a rule to write to devices.allow, or to devices.deny.
+type deviceCgroupChange struct {
+	allow  bool
+	device string // "type major:minor"
+	access string
+}
+
+func (d deviceCgroupChange) apply(dir string) error {
+	file := "devices.deny"
+	if d.allow {
+		file = "devices.allow"
+	}
+	return ioutil.WriteFile(filepath.Join(dir, file), []byte(d.device+" "+d.access), 0)
+}
+
+func (d deviceCgroupChange) revert() deviceCgroupChange {
+	return deviceCgroupChange{allow: !d.allow, device: d.device, access: d.access}
+}
+
+// deviceCgroupAccess returns the access allowed to each device by the
+// permissions, keyed by "type major:minor".
+func deviceCgroupAccess(permissions []specs.DeviceCgroup) map[string]string {
+	access := make(map[string]string)
+	for _, d := range permissions {
+		if !d.Allow {
+			continue
+		}
+		rule := strings.Fields(deviceCgroupString(d))
+		device := rule[0] + " " + rule[1]
+		access[device] = accessUnion(access[device], rule[2])
+	}
+	return access
+}
+
+// deviceCgroupChanges returns the changes to apply to the devices cgroup of a
+// container when its device cgroup rules change from old to rules. The
+// access allowed by keep is never revoked. New permissions are granted
+// before old ones are revoked, so that the container never loses access to
+// devices it keeps.
+func deviceCgroupChanges(old, rules, keep []specs.DeviceCgroup) []deviceCgroupChange {
+	oldAccess := deviceCgroupAccess(old)
+	newAccess := deviceCgroupAccess(rules)
+	keepAccess := deviceCgroupAccess(keep)
+
+	var changes []deviceCgroupChange
+	for _, device := range sortedDevices(newAccess) {
+		if added := accessDifference(newAccess[device], oldAccess[device]); added != "" {
+			changes = append(changes, deviceCgroupChange{allow: true, device: device, access: added})
+		}
+	}
+	for _, device := range sortedDevices(oldAccess) {
+		removed := accessDifference(oldAccess[device], accessUnion(newAccess[device], keepAccess[device]))
+		if removed != "" {
+			changes = append(changes, deviceCgroupChange{allow: false, device: device, access: removed})
+		}
+	}
+	return changes
+}
+
+func sortedDevices(access map[string]string) []string {
+	var devices []string
+	for device := range access {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+	return devices
+}
+
+// accessUnion returns the access modes ("r", "w" and "m") in a or b.
+func accessUnion(a, b string) string {
+	var access string
+	for _, m := range "rwm" {
+		if strings.ContainsRune(a, m) || strings.ContainsRune(b, m) {
+			access += string(m)
+		}
+	}
+	return access
+}
+
+// accessDifference returns the access modes in a that are not in b.
+func accessDifference(a, b string) string {
+	var access string
+	for _, m := range "rwm" {
+		if strings.ContainsRune(a, m) && !strings.ContainsRune(b, m) {
+			access += string(m)
+		}
+	}
+	return access
+}
+
+// deviceCgroupPath returns the devices cgroup directory of the process pid.
+func deviceCgroupPath(pid int) (string, error) {
+	mnt, root, err := cgroups.FindCgroupMountpointAndRoot("devices")
+	if err != nil {
+		return "", err
+	}
+	paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+	p, ok := paths["devices"]
+	if !ok {
+		return "", fmt.Errorf("no devices cgroup found for process %d", pid)
+	}
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mnt, rel), nil
+}
+
+// deviceCgroupString formats a device permission in the 'type major:minor mode'
+// form understood by devices.allow and devices.deny.
+func deviceCgroupString(d specs.DeviceCgroup) string {
+	typ, major, minor, access := "a", "*", "*", "rwm"
+	if d.Type != nil && *d.Type != "" {
+		typ = *d.Type
+	}
+	if d.Major != nil {
+		major = strconv.FormatInt(*d.Major, 10)
+	}
+	if d.Minor != nil {
+		minor = strconv.FormatInt(*d.Minor, 10)
+	}
+	if d.Access != nil && *d.Access != "" {
+		access = *d.Access
+	}
+	return fmt.Sprintf("%s %s:%s %s", typ, major, minor, access)
+}
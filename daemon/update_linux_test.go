@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"reflect"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func TestDeviceCgroupChanges(t *testing.T) {
+	rwm, rw, r := "rwm", "rw", "r"
+	c, major136, major188, major189 := "c", int64(136), int64(188), int64(189)
+	keep := []specs.DeviceCgroup{
+		{Allow: false, Access: &rwm},
+		{Allow: true, Type: &c, Major: &major136, Access: &rwm},
+	}
+
+	old := []specs.DeviceCgroup{
+		{Allow: true, Type: &c, Major: &major136, Access: &rwm},
+		{Allow: true, Type: &c, Major: &major188, Access: &rwm},
+	}
+	rules := []specs.DeviceCgroup{
+		{Allow: true, Type: &c, Major: &major188, Access: &r},
+		{Allow: true, Type: &c, Major: &major189, Access: &rw},
+	}
+
+	// new permissions come before revoked ones, and the access allowed by
+	// the defaults is kept even if the rule that also allowed it is removed
+	expected := []deviceCgroupChange{
+		{allow: true, device: "c 189:*", access: "rw"},
+		{allow: false, device: "c 188:*", access: "wm"},
+	}
+	if changes := deviceCgroupChanges(old, rules, keep); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected changes %v, got %v", expected, changes)
+	}
+
+	// removing all the rules only revokes what they allowed
+	expected = []deviceCgroupChange{
+		{allow: false, device: "c 188:*", access: "r"},
+		{allow: false, device: "c 189:*", access: "rw"},
+	}
+	if changes := deviceCgroupChanges(rules, nil, keep); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected changes %v, got %v", expected, changes)
+	}
+
+	// unchanged rules don't touch the cgroup
+	if changes := deviceCgroupChanges(rules, rules, keep); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
+
+func TestRuntimeDeviceCgroupsAreKept(t *testing.T) {
+	c := &container.Container{HostConfig: &containertypes.HostConfig{}}
+	keep, err := defaultDeviceCgroups(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	access := deviceCgroupAccess(keep)
+	for _, device := range []string{"c *:*", "b *:*", "c 136:*", "c 5:2", "c 10:200"} {
+		if access[device] == "" {
+			t.Fatalf("expected %s to be allowed by default, got %v", device, access)
+		}
+	}
+}
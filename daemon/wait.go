@@ -1,23 +1,26 @@
 package daemon
 
 import (
-	"time"
-
 	"golang.org/x/net/context"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
 )
 
-// ContainerWait stops processing until the given container is
-// stopped. If the container is not found, an error is returned. On a
-// successful stop, the exit code of the container is returned. On a
-// timeout, an error is returned. If you want to wait forever, supply
-// a negative duration for the timeout.
-func (daemon *Daemon) ContainerWait(name string, timeout time.Duration) (int, error) {
-	container, err := daemon.GetContainer(name)
+// ContainerWait waits until the given container is in a certain state
+// indicated by the given condition. If the container is not found, a nil
+// channel and non-nil error is returned immediately. If the container is
+// found, a status result will be sent on the returned channel once the wait
+// condition is met or if an error occurs waiting for the container (such as a
+// context timeout or cancellation). On a successful wait, the exit code of the
+// container is returned in the status with a nil Err() value.
+func (daemon *Daemon) ContainerWait(ctx context.Context, name string, condition containertypes.WaitCondition) (<-chan container.StateStatus, error) {
+	c, err := daemon.GetContainer(name)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
-	return container.WaitStop(timeout)
+	return c.Wait(ctx, condition), nil
 }
 
 // ContainerWaitWithContext returns a channel where exit code is sent
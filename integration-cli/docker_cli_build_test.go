@@ -7397,3 +7397,169 @@ func (s *DockerSuite) TestBuildWorkdirCmd(c *check.C) {
 	_, err := buildImage("testbuildworkdircmd", dockerFile, false)
 	c.Assert(err, checker.IsNil)
 }
+
+func (s *DockerSuite) TestBuildMultiStageCopyFromStage(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerfile := `
+FROM busybox AS build
+COPY foo /src/foo
+RUN echo -n bar > /src/bar
+
+FROM busybox
+COPY --from=build /src/ /dst/
+COPY --from=0 /src/foo /dst/foo2
+`
+	ctx, err := fakeContext(dockerfile, map[string]string{
+		"foo": "foo",
+	})
+	c.Assert(err, checker.IsNil)
+	defer ctx.Close()
+
+	name := "testbuildmultistagecopy"
+	out, err := buildImageFromContext(name, ctx, true)
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+
+	out, _ = dockerCmd(c, "run", "--rm", name, "cat", "/dst/foo", "/dst/bar", "/dst/foo2")
+	c.Assert(out, checker.Equals, "foobarfoo")
+
+	// only the last stage is committed as the result of the build
+	out, _ = dockerCmd(c, "run", "--rm", name, "ls", "/")
+	c.Assert(out, checker.Not(checker.Contains), "src")
+
+	// a second build is fully cached
+	_, out, err = buildImageFromContextWithOut(name, ctx, true)
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+	c.Assert(strings.Count(out, "Using cache"), checker.Equals, 4)
+}
+
+func (s *DockerSuite) TestBuildMultiStageCopyFromImage(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerfile := `
+FROM scratch
+COPY --from=busybox /bin/busybox /busybox
+`
+	name := "testbuildmultistagecopyfromimage"
+	out, err := buildImage(name, dockerfile, true)
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+
+	out, _ = dockerCmd(c, "run", "--rm", "--entrypoint", "/busybox", name, "echo", "ok")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "ok")
+}
+
+func (s *DockerSuite) TestBuildMultiStageInvalidFrom(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerfile := `
+FROM busybox AS build
+COPY --from=build /bin/busybox /busybox
+`
+	out, err := buildImage("testbuildmultistageinvalidfrom", dockerfile, true)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "refers current build block")
+
+	dockerfile = `
+FROM busybox AS build
+FROM busybox AS build
+`
+	out, err = buildImage("testbuildmultistageinvalidfrom", dockerfile, true)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "duplicate name build")
+}
+
+func (s *DockerSuite) TestBuildMultiStageTarget(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerfile := `
+FROM busybox AS build
+RUN touch /build
+
+FROM busybox AS final
+RUN touch /final
+`
+	name := "testbuildmultistagetarget"
+	out, err := buildImage(name, dockerfile, true, "--target", "build")
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+
+	dockerCmd(c, "run", "--rm", name, "test", "-f", "/build")
+	out, _, err = dockerCmdWithError("run", "--rm", name, "test", "-f", "/final")
+	c.Assert(err, checker.NotNil, check.Commentf("Output: %s", out))
+
+	out, err = buildImage(name, dockerfile, true, "--target", "nosuchstage")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "failed to reach build target nosuchstage in Dockerfile")
+}
+
+func (s *DockerSuite) TestBuildRunSecretMount(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	secretFile, err := ioutil.TempFile("", "build-secret")
+	c.Assert(err, checker.IsNil)
+	defer os.Remove(secretFile.Name())
+	_, err = secretFile.WriteString("s3cr3t")
+	c.Assert(err, checker.IsNil)
+	secretFile.Close()
+
+	dockerfile := `
+FROM busybox
+RUN --mount=type=secret,id=mysecret,required cat /run/secrets/mysecret > /copy
+RUN --mount=type=secret,id=mysecret,target=other grep -q s3cr3t /run/secrets/other
+RUN --mount=type=secret,id=notprovided echo skipped
+`
+	ctx, err := fakeContext(dockerfile, nil)
+	c.Assert(err, checker.IsNil)
+	defer ctx.Close()
+
+	name := "testbuildrunsecretmount"
+	_, out, err := buildImageFromContextWithOut(name, ctx, true, "--secret", "id=mysecret,src="+secretFile.Name())
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+
+	// the secret is usable during the build, but is not committed
+	out, _ = dockerCmd(c, "run", "--rm", name, "cat", "/copy")
+	c.Assert(out, checker.Equals, "s3cr3t")
+	out, _, err = dockerCmdWithError("run", "--rm", name, "test", "-e", "/run/secrets/mysecret")
+	c.Assert(err, checker.NotNil, check.Commentf("Output: %s", out))
+
+	_, err = buildImage(name+"-required", dockerfile, false)
+	c.Assert(err, checker.NotNil)
+	c.Assert(err.Error(), checker.Contains, "secret mysecret is required but was not provided to the build")
+}
+
+func (s *DockerSuite) TestBuildCopyAddChown(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	dockerfile := `
+FROM busybox
+RUN echo 'app:x:1001:1002::/home/app:/bin/sh' >> /etc/passwd && echo 'appgroup:x:1002:' >> /etc/group
+COPY --chown=app:appgroup foo /copy/foo
+COPY --chown=2000 dir /copy/dir/
+ADD --chown=3000:3001 foo /add/foo
+COPY foo /root-owned/foo
+`
+	ctx, err := fakeContext(dockerfile, map[string]string{
+		"foo":     "foo",
+		"dir/bar": "bar",
+	})
+	c.Assert(err, checker.IsNil)
+	defer ctx.Close()
+
+	name := "testbuildcopyaddchown"
+	_, out, err := buildImageFromContextWithOut(name, ctx, true)
+	c.Assert(err, checker.IsNil, check.Commentf("Output: %s", out))
+
+	out, _ = dockerCmd(c, "run", "--rm", name, "stat", "-c", "%u:%g", "/copy/foo", "/copy/dir", "/copy/dir/bar", "/add/foo", "/root-owned/foo")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, []string{"1001:1002", "2000:0", "2000:0", "3000:3001", "0:0"})
+
+	dockerfile = `
+FROM busybox
+COPY --chown=nosuchuser foo /foo
+`
+	ctx, err = fakeContext(dockerfile, map[string]string{"foo": "foo"})
+	c.Assert(err, checker.IsNil)
+	defer ctx.Close()
+
+	_, err = buildImageFromContext(name+"-invalid", ctx, true)
+	c.Assert(err, checker.NotNil)
+	c.Assert(err.Error(), checker.Contains, "unable to resolve --chown=nosuchuser")
+}
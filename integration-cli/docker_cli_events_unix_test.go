@@ -429,7 +429,7 @@ func (s *DockerDaemonSuite) TestDaemonEvents(c *check.C) {
 	out, err = s.d.Cmd("events", "--since=0", "--until", daemonUnixTime(c))
 	c.Assert(err, checker.IsNil)
 
-	c.Assert(out, checker.Contains, fmt.Sprintf("daemon reload %s (cluster-advertise=, cluster-store=, cluster-store-opts={}, debug=true, default-runtime=runc, insecure-registries=[], labels=[\"bar=foo\"], live-restore=false, max-concurrent-downloads=1, max-concurrent-uploads=5, name=%s, runtimes=runc:{docker-runc []}, shutdown-timeout=10)", daemonID, daemonName))
+	c.Assert(out, checker.Contains, fmt.Sprintf("daemon reload %s (authorization-plugins=[], changed-keys=labels,max-concurrent-downloads,shutdown-timeout, cluster-advertise=, cluster-store=, cluster-store-opts={}, debug=true, default-runtime=runc, default-ulimits=[], dns=[], insecure-registries=[], labels=[\"bar=foo\"], live-restore=false, log-driver=json-file, log-opts={}, max-concurrent-downloads=1, max-concurrent-uploads=5, name=%s, registry-host-mirrors={}, registry-mirrors=[], runtimes=runc:{docker-runc []}, shutdown-timeout=10)", daemonID, daemonName))
 }
 
 func (s *DockerDaemonSuite) TestDaemonEventsWithFilters(c *check.C) {
@@ -484,3 +484,24 @@ func (s *DockerDaemonSuite) TestDaemonEventsWithFilters(c *check.C) {
 	c.Assert(err, checker.IsNil)
 	c.Assert(out, checker.Not(checker.Contains), fmt.Sprintf("daemon reload %s", daemonID))
 }
+
+func (s *DockerDaemonSuite) TestDaemonEventsSinceRestart(c *check.C) {
+	testRequires(c, SameHostDaemon, DaemonIsLinux)
+
+	s.d.Start(c)
+	out, err := s.d.Cmd("volume", "create", "journaled")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	s.d.Restart(c)
+
+	out, err = s.d.Cmd("events", "--since=0", "--until", daemonUnixTime(c), "--filter", "volume=journaled")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "volume create journaled")
+
+	// the events log is not used when it is disabled
+	s.d.Restart(c, "--events-log-max-size=0")
+
+	out, err = s.d.Cmd("events", "--since=0", "--until", daemonUnixTime(c), "--filter", "volume=journaled")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Not(checker.Contains), "volume create journaled")
+}
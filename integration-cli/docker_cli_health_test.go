@@ -167,3 +167,95 @@ func (s *DockerSuite) TestHealth(c *check.C) {
 	c.Check(out, checker.Equals, "[CMD cat /my status]\n")
 
 }
+
+func (s *DockerSuite) TestHealthHTTPAndTCP(c *check.C) {
+	testRequires(c, DaemonIsLinux) // busybox doesn't work on Windows
+
+	imageName := "testhealthhttp"
+	_, err := buildImage(imageName,
+		`FROM busybox
+		RUN mkdir /www && echo OK > /www/index.html
+		CMD ["httpd", "-f", "-p", "8080", "-h", "/www"]
+		STOPSIGNAL SIGKILL
+		HEALTHCHECK --interval=1s HTTP http://localhost:8080/index.html 200`,
+		true)
+	c.Check(err, check.IsNil)
+
+	out, _ := dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.Test}}", imageName)
+	c.Check(out, checker.Equals, "[HTTP http://localhost:8080/index.html 200]\n")
+
+	// The probe is made from within the container's network namespace,
+	// so no port needs to be published.
+	dockerCmd(c, "run", "-d", "--name=http_healthcheck", imageName)
+	waitForHealthStatus(c, "http_healthcheck", "starting", "healthy")
+	health := getHealth(c, "http_healthcheck")
+	last := health.Log[len(health.Log)-1]
+	c.Check(last.ExitCode, checker.Equals, 0)
+	c.Check(last.Output, checker.Contains, "200 OK")
+
+	// A missing page is outside of the healthy status range
+	dockerCmd(c, "exec", "http_healthcheck", "rm", "/www/index.html")
+	waitForHealthStatus(c, "http_healthcheck", "healthy", "unhealthy")
+	dockerCmd(c, "rm", "-f", "http_healthcheck")
+
+	dockerCmd(c, "run", "-d", "--name=tcp_healthcheck",
+		"--health-interval=1s",
+		"--health-tcp=localhost:8080",
+		imageName)
+	waitForHealthStatus(c, "tcp_healthcheck", "starting", "healthy")
+	out, _ = dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.Test}}", "tcp_healthcheck")
+	c.Check(out, checker.Equals, "[TCP localhost:8080]\n")
+	dockerCmd(c, "rm", "-f", "tcp_healthcheck")
+
+	out, _, err = dockerCmdWithError("create", "--health-cmd=true", "--health-tcp=localhost:8080", imageName)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "--health-cmd, --health-http and --health-tcp are mutually exclusive")
+
+	out, _, err = dockerCmdWithError("create", "--health-tcp=localhost", imageName)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid TCP health check address")
+}
+
+func (s *DockerSuite) TestHealthStartPeriod(c *check.C) {
+	testRequires(c, DaemonIsLinux) // busybox doesn't work on Windows
+
+	imageName := "testhealthstartperiod"
+	_, err := buildImage(imageName,
+		`FROM busybox
+		CMD ["/bin/sleep", "120"]
+		STOPSIGNAL SIGKILL
+		HEALTHCHECK --interval=1s --retries=1 --start-period=1m \
+		  CMD cat /status`,
+		true)
+	c.Check(err, check.IsNil)
+
+	out, _ := dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.StartPeriod}}", imageName)
+	c.Check(out, checker.Equals, "1m0s\n")
+
+	// Failures during the start period are logged, but do not make the
+	// container unhealthy.
+	name := "test_health_start_period"
+	dockerCmd(c, "run", "-d", "--name", name, imageName)
+	for {
+		health := getHealth(c, name)
+		if len(health.Log) >= 2 {
+			c.Check(health.Status, checker.Equals, "starting")
+			c.Check(health.FailingStreak, checker.Equals, 0)
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The first success ends the start period
+	dockerCmd(c, "exec", name, "touch", "/status")
+	waitForHealthStatus(c, name, "starting", "healthy")
+	dockerCmd(c, "exec", name, "rm", "/status")
+	waitForHealthStatus(c, name, "healthy", "unhealthy")
+	dockerCmd(c, "rm", "-f", name)
+
+	out, _ = dockerCmd(c, "create", "--health-start-period=30s", imageName)
+	id := strings.TrimSpace(out)
+	out, _ = dockerCmd(c, "inspect", "--format={{.Config.Healthcheck.StartPeriod}}", id)
+	c.Check(out, checker.Equals, "30s\n")
+	dockerCmd(c, "rm", id)
+}
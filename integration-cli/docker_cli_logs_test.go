@@ -326,3 +326,15 @@ func (s *DockerSuite) TestLogsWithDetails(c *check.C) {
 	c.Assert(details[0], checker.Equals, "baz=qux")
 	c.Assert(details[1], checker.Equals, "foo=bar")
 }
+
+func (s *DockerSuite) TestLogsFromLocalCache(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--name=test", "--log-driver=syslog", "--log-opt", "syslog-address=udp://127.0.0.1:514", "busybox", "echo", "hello")
+	out, _ := dockerCmd(c, "logs", "test")
+	c.Assert(out, checker.Equals, "hello\n")
+
+	dockerCmd(c, "run", "--name=test-no-cache", "--log-driver=syslog", "--log-opt", "syslog-address=udp://127.0.0.1:514", "--log-opt", "cache-disabled=true", "busybox", "echo", "hello")
+	out, _, err := dockerCmdWithError("logs", "test-no-cache")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "configured logging driver does not support reading")
+}
@@ -142,6 +142,33 @@ func (s *DockerSuite) TestPluginInstallArgs(c *check.C) {
 	c.Assert(strings.TrimSpace(env), checker.Equals, "[DEBUG=1]")
 }
 
+func (s *DockerSuite) TestPluginUpgrade(c *check.C) {
+	testRequires(c, DaemonIsLinux, IsAmd64, Network)
+	dockerCmd(c, "plugin", "install", "--grant-all-permissions", "--disable", pNameWithTag)
+	dockerCmd(c, "plugin", "set", pNameWithTag, "DEBUG=1")
+	dockerCmd(c, "plugin", "enable", pNameWithTag)
+	dockerCmd(c, "volume", "create", "-d", pNameWithTag, "--name", "testvol1")
+
+	id, _ := dockerCmd(c, "plugin", "inspect", "-f", "{{.Id}}", pNameWithTag)
+
+	out, _ := dockerCmd(c, "plugin", "upgrade", "--grant-all-permissions", pNameWithTag, pNameWithTag)
+	c.Assert(out, checker.Contains, "Upgraded plugin "+pNameWithTag)
+
+	out, _ = dockerCmd(c, "plugin", "inspect", "-f", "{{.Id}} {{.Enabled}} {{.Settings.Env}}", pNameWithTag)
+	c.Assert(strings.TrimSpace(out), checker.Equals, strings.TrimSpace(id)+" true [DEBUG=1]")
+
+	// the volume still references the plugin by name
+	dockerCmd(c, "volume", "inspect", "testvol1")
+	dockerCmd(c, "volume", "rm", "testvol1")
+}
+
+func (s *DockerSuite) TestPluginUpgradeNotInstalled(c *check.C) {
+	testRequires(c, DaemonIsLinux, IsAmd64, Network)
+	out, _, err := dockerCmdWithError("plugin", "upgrade", "--grant-all-permissions", pNameWithTag, pNameWithTag)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "not found")
+}
+
 func (s *DockerRegistrySuite) TestPluginInstallImage(c *check.C) {
 	testRequires(c, DaemonIsLinux, IsAmd64)
 
@@ -288,3 +288,51 @@ func (s *DockerSuite) TestRestartAutoRemoveContainer(c *check.C) {
 	// Kill the container to make sure it will be removed
 	dockerCmd(c, "kill", id)
 }
+
+func (s *DockerSuite) TestRestartPolicyBackoff(c *check.C) {
+	out, _, err := dockerCmdWithError("create", "--restart=always", "--restart-delay=-1s", "busybox")
+	c.Assert(err, check.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "--restart-delay cannot be negative")
+
+	out, _, err = dockerCmdWithError("create", "--restart-crashloop-threshold=3", "busybox")
+	c.Assert(err, check.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "cannot be used without a restart policy")
+
+	out, _ = dockerCmd(c, "create", "--restart=always", "--restart-delay=1s", "--restart-max-delay=1m", "--restart-reset-window=30s", "--restart-crashloop-threshold=3", "--restart-crashloop-window=10m", "busybox")
+
+	id := strings.TrimSpace(out)
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.InitialDelay"), checker.Equals, "1s")
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.MaxDelay"), checker.Equals, "1m0s")
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.ResetWindow"), checker.Equals, "30s")
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.CrashLoopThreshold"), checker.Equals, "3")
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.CrashLoopWindow"), checker.Equals, "10m0s")
+}
+
+func (s *DockerSuite) TestRestartPolicyCrashLoop(c *check.C) {
+	since := daemonUnixTime(c)
+
+	// the container enters the crash loop on its second restart, and then
+	// waits 4s in the restarting state before being restarted again.
+	out, _ := dockerCmd(c, "run", "-d", "--restart=always", "--restart-delay=2s", "--restart-crashloop-threshold=1", "busybox", "false")
+	id := strings.TrimSpace(out)
+
+	err := waitInspect(id, "{{.State.Status}} {{.State.CrashLooping}}", "restarting true", 30*time.Second)
+	c.Assert(err, checker.IsNil)
+
+	out, _ = dockerCmd(c, "ps", "-q", "--no-trunc", "--filter", "crashloop=true")
+	c.Assert(strings.TrimSpace(out), checker.Equals, id)
+
+	out, _ = dockerCmd(c, "ps", "-q", "--no-trunc", "--filter", "status=restarting")
+	c.Assert(strings.TrimSpace(out), checker.Equals, id)
+
+	// changing the policy keeps the crash loop detection
+	dockerCmd(c, "update", "--restart=on-failure", id)
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.CrashLoopThreshold"), checker.Equals, "1")
+	c.Assert(inspectField(c, id, "HostConfig.RestartPolicy.InitialDelay"), checker.Equals, "2s")
+
+	out, _ = dockerCmd(c, "events", "--since", since, "--until", daemonUnixTime(c), "--filter", "container="+id, "--filter", "event=crashloop")
+	c.Assert(strings.Count(out, "crashloop"), checker.Equals, 1, check.Commentf(out))
+
+	dockerCmd(c, "stop", id)
+	c.Assert(inspectField(c, id, "State.CrashLooping"), checker.Equals, "false")
+}
@@ -90,6 +90,22 @@ func (s *DockerSuite) TestRunDeviceDirectory(c *check.C) {
 	c.Assert(strings.Trim(out, "\r\n"), checker.Contains, "seq", check.Commentf("expected output /dev/othersnd/seq"))
 }
 
+func (s *DockerSuite) TestRunDeviceCgroupRule(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	deviceRule := "c 7:128 rwm"
+
+	out, _ := dockerCmd(c, "run", "--rm", "busybox", "cat", "/sys/fs/cgroup/devices/devices.list")
+	c.Assert(out, checker.Not(checker.Contains), deviceRule)
+
+	out, _ = dockerCmd(c, "run", "--rm", "--device-cgroup-rule", deviceRule, "busybox", "cat", "/sys/fs/cgroup/devices/devices.list")
+	c.Assert(out, checker.Contains, deviceRule)
+
+	out, _, err := dockerCmdWithError("run", "--rm", "--device-cgroup-rule", "c 7 rwm", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid device cgroup format 'c 7 rwm'")
+}
+
 // TestRunDetach checks attaching and detaching with the default escape sequence.
 func (s *DockerSuite) TestRunAttachDetach(c *check.C) {
 	name := "attach-detach"
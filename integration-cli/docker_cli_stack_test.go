@@ -1,9 +1,12 @@
 package main
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/go-check/check"
 )
@@ -65,6 +68,37 @@ func (s *DockerSwarmSuite) TestStackDeployComposeFile(c *check.C) {
 	c.Assert(out, check.Equals, "NAME  SERVICES\n")
 }
 
+func (s *DockerSwarmSuite) TestStackDeployPrune(c *check.C) {
+	testRequires(c, ExperimentalDaemon)
+	d := s.AddDaemon(c, true, true)
+
+	testStackName := "testdeploy"
+	out, err := d.Cmd("stack", "deploy", "--compose-file", "fixtures/deploy/default.yaml", testStackName)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	// without --prune, the service that is no longer declared is kept
+	out, err = d.Cmd("stack", "deploy", "--compose-file", "fixtures/deploy/remove.yaml", testStackName)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Not(checker.Contains), "Removing service")
+
+	out, err = d.Cmd("stack", "ls")
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, check.Equals, "NAME        SERVICES\n"+"testdeploy  2\n")
+
+	out, err = d.Cmd("stack", "deploy", "--compose-file", "fixtures/deploy/remove.yaml", "--prune", testStackName)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "Removing service testdeploy_db\n")
+	c.Assert(out, checker.Not(checker.Contains), "Removing service testdeploy_web")
+
+	out, err = d.Cmd("stack", "ls")
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, check.Equals, "NAME        SERVICES\n"+"testdeploy  1\n")
+
+	out, err = d.Cmd("stack", "deploy", "--compose-file", "fixtures/deploy/remove.yaml", "--prune-networks", testStackName)
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "--prune-networks requires --prune")
+}
+
 // testDAB is the DAB JSON used for testing.
 // TODO: Use template/text and substitute "Image" with the result of
 // `docker inspect --format '{{index .RepoDigests 0}}' busybox:latest`
@@ -83,6 +117,36 @@ const testDAB = `{
     }
 }`
 
+func (s *DockerSwarmSuite) TestStackDeployWithSecrets(c *check.C) {
+	testRequires(c, ExperimentalDaemon)
+	d := s.AddDaemon(c, true, true)
+
+	id := d.CreateSecret(c, swarm.SecretSpec{
+		Annotations: swarm.Annotations{Name: "super"},
+		Data:        []byte("external"),
+	})
+	c.Assert(id, checker.Not(checker.Equals), "")
+
+	out, err := d.Cmd("stack", "deploy", "--compose-file", "fixtures/deploy/secrets.yaml", "testdeploy")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "Creating secret testdeploy_special\n")
+
+	out, err = d.Cmd("service", "inspect", "--format", "{{ json .Spec.TaskTemplate.ContainerSpec.Secrets }}", "testdeploy_web")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	var refs []swarm.SecretReference
+	c.Assert(json.Unmarshal([]byte(out), &refs), checker.IsNil)
+	c.Assert(refs, checker.HasLen, 2)
+
+	// removing the stack removes its secrets, but not the external ones
+	out, err = d.Cmd("stack", "rm", "testdeploy")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "Removing secret testdeploy_special")
+
+	out, err = d.Cmd("secret", "ls", "--quiet")
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(strings.TrimSpace(out), checker.Equals, id)
+}
+
 func (s *DockerSwarmSuite) TestStackDeployWithDAB(c *check.C) {
 	testRequires(c, ExperimentalDaemon)
 	// setup
@@ -281,3 +281,21 @@ func (s *DockerSuite) TestUpdateNotAffectMonitorRestartPolicy(c *check.C) {
 	c.Assert(err, checker.IsNil)
 	c.Assert(waitRun(id), checker.IsNil)
 }
+
+func (s *DockerSuite) TestUpdateDeviceCgroupRule(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+
+	name := "test-update-container"
+	file := "/sys/fs/cgroup/devices/devices.list"
+	dockerCmd(c, "run", "-d", "--name", name, "--device-cgroup-rule", "c 7:128 rwm", "busybox", "top")
+
+	out, _ := dockerCmd(c, "exec", name, "cat", file)
+	c.Assert(out, checker.Contains, "c 7:128 rwm")
+
+	dockerCmd(c, "update", "--device-cgroup-rule", "c 188:* rw", name)
+	c.Assert(inspectField(c, name, "HostConfig.DeviceCgroupRules"), checker.Equals, "[c 188:* rw]")
+
+	out, _ = dockerCmd(c, "exec", name, "cat", file)
+	c.Assert(out, checker.Contains, "c 188:* rw")
+	c.Assert(out, checker.Not(checker.Contains), "c 7:128 rwm")
+}
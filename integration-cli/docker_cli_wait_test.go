@@ -95,3 +95,81 @@ func (s *DockerSuite) TestWaitBlockedExitRandom(c *check.C) {
 		c.Fatal("timeout waiting for `docker wait` to exit")
 	}
 }
+
+// next-exit ignores the current stopped state and waits for the next exit
+func (s *DockerSuite) TestWaitConditionNextExit(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "create", "busybox", "sh", "-c", "exit 42")
+	containerID := strings.TrimSpace(out)
+
+	waitCmd := exec.Command(dockerBinary, "wait", "--condition=next-exit", containerID)
+	waitCmdOut := bytes.NewBuffer(nil)
+	waitCmd.Stdout = waitCmdOut
+	c.Assert(waitCmd.Start(), checker.IsNil)
+
+	chWait := make(chan error)
+	go func() {
+		chWait <- waitCmd.Wait()
+	}()
+
+	select {
+	case err := <-chWait:
+		c.Fatalf("`docker wait --condition=next-exit` returned before the container ran: %v, %s", err, waitCmdOut.String())
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	dockerCmd(c, "start", containerID)
+
+	select {
+	case err := <-chWait:
+		c.Assert(err, checker.IsNil, check.Commentf(waitCmdOut.String()))
+		c.Assert(strings.TrimSpace(waitCmdOut.String()), checker.Equals, "42")
+	case <-time.After(10 * time.Second):
+		waitCmd.Process.Kill()
+		c.Fatal("timeout waiting for `docker wait` to exit")
+	}
+}
+
+// removed waits until the container is removed
+func (s *DockerSuite) TestWaitConditionRemoved(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "exit 7")
+	containerID := strings.TrimSpace(out)
+	c.Assert(waitExited(containerID, 30*time.Second), checker.IsNil)
+
+	waitCmd := exec.Command(dockerBinary, "wait", "--condition=removed", containerID)
+	waitCmdOut := bytes.NewBuffer(nil)
+	waitCmd.Stdout = waitCmdOut
+	c.Assert(waitCmd.Start(), checker.IsNil)
+
+	chWait := make(chan error)
+	go func() {
+		chWait <- waitCmd.Wait()
+	}()
+
+	select {
+	case err := <-chWait:
+		c.Fatalf("`docker wait --condition=removed` returned before the container was removed: %v, %s", err, waitCmdOut.String())
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	dockerCmd(c, "rm", containerID)
+
+	select {
+	case err := <-chWait:
+		c.Assert(err, checker.IsNil, check.Commentf(waitCmdOut.String()))
+		c.Assert(strings.TrimSpace(waitCmdOut.String()), checker.Equals, "7")
+	case <-time.After(10 * time.Second):
+		waitCmd.Process.Kill()
+		c.Fatal("timeout waiting for `docker wait` to exit")
+	}
+}
+
+func (s *DockerSuite) TestWaitInvalidCondition(c *check.C) {
+	out, _ := dockerCmd(c, "create", "busybox", "true")
+	containerID := strings.TrimSpace(out)
+
+	out, _, err := dockerCmdWithError("wait", "--condition=foo", containerID)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "invalid condition")
+}
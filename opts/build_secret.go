@@ -0,0 +1,78 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BuildSecretOpt is a Value type for parsing the secrets passed to a build
+type BuildSecretOpt struct {
+	values map[string]string
+}
+
+// Set a new build secret value
+func (o *BuildSecretOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	var id, source string
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		value := parts[1]
+		switch key {
+		case "id":
+			id = value
+		case "src", "source":
+			source = value
+		default:
+			return fmt.Errorf("invalid field in build secret: %s", key)
+		}
+	}
+
+	if id == "" {
+		return fmt.Errorf("id is required")
+	}
+	if source == "" {
+		return fmt.Errorf("src is required")
+	}
+	if o.values == nil {
+		o.values = make(map[string]string)
+	}
+	if _, exists := o.values[id]; exists {
+		return fmt.Errorf("duplicate build secret: %s", id)
+	}
+
+	o.values[id] = source
+	return nil
+}
+
+// Type returns the type of this option
+func (o *BuildSecretOpt) Type() string {
+	return "build-secret"
+}
+
+// String returns a string repr of this option
+func (o *BuildSecretOpt) String() string {
+	secrets := []string{}
+	for id, source := range o.values {
+		secrets = append(secrets, fmt.Sprintf("%s -> %s", source, id))
+	}
+	sort.Strings(secrets)
+	return strings.Join(secrets, ", ")
+}
+
+// Value returns the source file of each build secret, keyed by secret ID
+func (o *BuildSecretOpt) Value() map[string]string {
+	return o.values
+}
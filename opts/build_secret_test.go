@@ -0,0 +1,32 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestBuildSecretOptions(t *testing.T) {
+	var opt BuildSecretOpt
+
+	assert.NilError(t, opt.Set("id=npmrc,src=/home/user/.npmrc"))
+	assert.NilError(t, opt.Set("id=key,source=./key.pem"))
+
+	values := opt.Value()
+	assert.Equal(t, len(values), 2)
+	assert.Equal(t, values["npmrc"], "/home/user/.npmrc")
+	assert.Equal(t, values["key"], "./key.pem")
+	assert.Equal(t, opt.String(), "./key.pem -> key, /home/user/.npmrc -> npmrc")
+}
+
+func TestBuildSecretOptionsInvalid(t *testing.T) {
+	var opt BuildSecretOpt
+
+	assert.Error(t, opt.Set("src=/home/user/.npmrc"), "id is required")
+	assert.Error(t, opt.Set("id=npmrc"), "src is required")
+	assert.Error(t, opt.Set("id=npmrc,target=foo"), "invalid field in build secret")
+	assert.Error(t, opt.Set("npmrc"), "must be a key=value pair")
+
+	assert.NilError(t, opt.Set("id=npmrc,src=a"))
+	assert.Error(t, opt.Set("id=npmrc,src=b"), "duplicate build secret")
+}
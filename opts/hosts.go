@@ -60,6 +60,30 @@ func ParseHost(defaultToTLS bool, val string) (string, error) {
 	return host, nil
 }
 
+// ValidateClientHost validates that the specified string is a valid host to
+// connect to. Unlike ValidateHost, it accepts ssh:// addresses, which only the
+// client can use.
+func ValidateClientHost(val string) (string, error) {
+	host := strings.TrimSpace(val)
+	if strings.HasPrefix(host, "ssh://") {
+		if _, err := parseSSHAddr(host); err != nil {
+			return val, err
+		}
+		return val, nil
+	}
+	return ValidateHost(val)
+}
+
+// ParseClientHost and set defaults for a host string the client connects to.
+// Unlike ParseHost, it accepts ssh:// addresses.
+func ParseClientHost(defaultToTLS bool, val string) (string, error) {
+	host := strings.TrimSpace(val)
+	if strings.HasPrefix(host, "ssh://") {
+		return parseSSHAddr(host)
+	}
+	return ParseHost(defaultToTLS, val)
+}
+
 // parseDockerDaemonHost parses the specified address and returns an address that will be used as the host.
 // Depending of the address specified, this may return one of the global Default* strings defined in hosts.go.
 func parseDockerDaemonHost(addr string) (string, error) {
@@ -82,6 +106,25 @@ func parseDockerDaemonHost(addr string) (string, error) {
 	}
 }
 
+// parseSSHAddr validates an ssh://[user@]host[:port] address. The daemon is
+// reached through the docker CLI of the remote host, so the address cannot
+// have a path.
+func parseSSHAddr(addr string) (string, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("Invalid ssh address, expected ssh://[user@]host[:port]: %s", addr)
+	}
+	if _, port, err := net.SplitHostPort(u.Host); err == nil {
+		if _, err := strconv.Atoi(port); err != nil {
+			return "", fmt.Errorf("Invalid ssh address, bad port: %s", addr)
+		}
+	}
+	return addr, nil
+}
+
 // parseSimpleProtoAddr parses and validates that the specified address is a valid
 // socket address for simple protocols like unix and npipe. It returns a formatted
 // socket address, either using the address parsed from addr, or the contents of
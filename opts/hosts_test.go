@@ -59,6 +59,7 @@ func TestParseDockerDaemonHost(t *testing.T) {
 		"tcp://unix:///run/docker.sock": "Invalid proto, expected tcp: unix:///run/docker.sock",
 		" tcp://:7777/path ":            "Invalid bind address format:  tcp://:7777/path ",
 		"":                              "Invalid bind address format: ",
+		"ssh://host":                    "Invalid bind address format: ssh://host",
 	}
 	valids := map[string]string{
 		"0.0.0.1:":                    "tcp://0.0.0.1:2375",
@@ -93,6 +94,36 @@ func TestParseDockerDaemonHost(t *testing.T) {
 	}
 }
 
+func TestParseClientHost(t *testing.T) {
+	invalids := map[string]string{
+		"ssh://":          "Invalid ssh address, expected ssh://[user@]host[:port]: ssh://",
+		"ssh://host/path": "Invalid ssh address, expected ssh://[user@]host[:port]: ssh://host/path",
+		"udp://127.0.0.1": "Invalid bind address format: udp://127.0.0.1",
+	}
+	valids := map[string]string{
+		"ssh://host":              "ssh://host",
+		"ssh://me@host:2222":      "ssh://me@host:2222",
+		" ssh://host ":            "ssh://host",
+		"unix:///run/docker.sock": "unix:///run/docker.sock",
+	}
+	for invalidAddr, expectedError := range invalids {
+		if _, err := ValidateClientHost(invalidAddr); err == nil || err.Error() != expectedError {
+			t.Errorf("%v address expected error %q on validation, got %q", invalidAddr, expectedError, err)
+		}
+		if addr, err := ParseClientHost(false, invalidAddr); err == nil || err.Error() != expectedError {
+			t.Errorf("%v address expected error %q return, got %q and addr %v", invalidAddr, expectedError, err, addr)
+		}
+	}
+	for validAddr, expectedAddr := range valids {
+		if _, err := ValidateClientHost(validAddr); err != nil {
+			t.Errorf("%v -> expected no validation error, got (%v)", validAddr, err)
+		}
+		if addr, err := ParseClientHost(false, validAddr); err != nil || addr != expectedAddr {
+			t.Errorf("%v -> expected %v, got (%v) addr (%v)", validAddr, expectedAddr, err, addr)
+		}
+	}
+}
+
 func TestParseTCP(t *testing.T) {
 	var (
 		defaultHTTPHost = "tcp://127.0.0.1:2376"
@@ -276,33 +276,57 @@ func canonicalTarName(name string, isDir bool) (string, error) {
 	return name, nil
 }
 
-// addTarFile adds to the tar archive a file from `path` as `name`
-func (ta *tarAppender) addTarFile(path, name string) error {
-	fi, err := os.Lstat(path)
-	if err != nil {
-		return err
-	}
-
+// FileInfoHeader creates a populated tar header for the file at `path`,
+// stored in the archive as `name`. Compared to tar.FileInfoHeader it also
+// canonicalizes the name, fills in device numbers and captures the
+// security.capability extended attribute.
+func FileInfoHeader(path, name string, fi os.FileInfo) (*tar.Header, error) {
 	link := ""
 	if fi.Mode()&os.ModeSymlink != 0 {
+		var err error
 		if link, err = os.Readlink(path); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	hdr, err := tar.FileInfoHeader(fi, link)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	hdr.Mode = int64(chmodTarEntry(os.FileMode(hdr.Mode)))
 
 	name, err = canonicalTarName(name, fi.IsDir())
 	if err != nil {
-		return fmt.Errorf("tar: cannot canonicalize path: %v", err)
+		return nil, fmt.Errorf("tar: cannot canonicalize path: %v", err)
 	}
 	hdr.Name = name
 
-	inode, err := setHeaderForSpecialDevice(hdr, ta, name, fi.Sys())
+	if err := setHeaderForSpecialDevice(hdr, name, fi.Sys()); err != nil {
+		return nil, err
+	}
+
+	capability, _ := system.Lgetxattr(path, "security.capability")
+	if capability != nil {
+		hdr.Xattrs = make(map[string]string)
+		hdr.Xattrs["security.capability"] = string(capability)
+	}
+	return hdr, nil
+}
+
+// addTarFile adds to the tar archive a file from `path` as `name`
+func (ta *tarAppender) addTarFile(path, name string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	hdr, err := FileInfoHeader(path, name, fi)
+	if err != nil {
+		return err
+	}
+	name = hdr.Name
+
+	inode, err := getInodeFromStat(fi.Sys())
 	if err != nil {
 		return err
 	}
@@ -321,12 +345,6 @@ func (ta *tarAppender) addTarFile(path, name string) error {
 		}
 	}
 
-	capability, _ := system.Lgetxattr(path, "security.capability")
-	if capability != nil {
-		hdr.Xattrs = make(map[string]string)
-		hdr.Xattrs["security.capability"] = string(capability)
-	}
-
 	//handle re-mapping container ID mappings back to host ID mappings before
 	//writing tar headers/files. We skip whiteout files because they were written
 	//by the kernel and already have proper ownership relative to the host
@@ -3,6 +3,7 @@
 package tailfile
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"io"
@@ -64,3 +65,35 @@ func TailFile(f io.ReadSeeker, n int) ([][]byte, error) {
 	}
 	return lines[:len(lines)-1], nil
 }
+
+// TailReader returns last n lines of reader r. Unlike TailFile, the reader
+// does not need to be seekable, so it can be used on compressed streams; it
+// is read until EOF. As with TailFile, trailing data that is not terminated
+// by a newline is ignored.
+func TailReader(r io.Reader, n int) ([][]byte, error) {
+	if n <= 0 {
+		return nil, ErrNonPositiveLinesNumber
+	}
+	var (
+		rd    = bufio.NewReader(r)
+		lines = make([][]byte, n)
+		cnt   int
+	)
+	for {
+		line, err := rd.ReadBytes(eol[0])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines[cnt%n] = line[:len(line)-1]
+		cnt++
+	}
+	if cnt < n {
+		return lines[:cnt], nil
+	}
+	// reorder the ring buffer, oldest line first
+	start := cnt % n
+	return append(lines[start:], lines[:start]...), nil
+}
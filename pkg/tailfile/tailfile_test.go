@@ -1,6 +1,7 @@
 package tailfile
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -146,3 +147,34 @@ func BenchmarkTail(b *testing.B) {
 		}
 	}
 }
+
+func TestTailReader(t *testing.T) {
+	input := "first line\nsecond line\nthird line\nfourth line\ntruncated line"
+	for _, tc := range []struct {
+		n        int
+		expected []string
+	}{
+		{n: 2, expected: []string{"third line", "fourth line"}},
+		{n: 4, expected: []string{"first line", "second line", "third line", "fourth line"}},
+		{n: 10, expected: []string{"first line", "second line", "third line", "fourth line"}},
+	} {
+		res, err := TailReader(bytes.NewBufferString(input), tc.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != len(tc.expected) {
+			t.Fatalf("Expected %d lines for n=%d, got %d", len(tc.expected), tc.n, len(res))
+		}
+		for i, l := range res {
+			if tc.expected[i] != string(l) {
+				t.Fatalf("Expected line %s, got %s", tc.expected[i], l)
+			}
+		}
+	}
+}
+
+func TestTailReaderNonPositive(t *testing.T) {
+	if _, err := TailReader(bytes.NewBufferString("line\n"), 0); err != ErrNonPositiveLinesNumber {
+		t.Fatalf("Expected ErrNonPositiveLinesNumber, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package tarsum
 import (
 	"archive/tar"
 	"errors"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
@@ -148,3 +149,10 @@ func getTarHeaderSelector(v Version) (tarHeaderSelector, error) {
 
 	return headerSelector, nil
 }
+
+// WriteV1Header writes a tar header to a writer in V1 tarsum format.
+func WriteV1Header(h *tar.Header, w io.Writer) {
+	for _, elem := range v1TarHeaderSelect(h) {
+		w.Write([]byte(elem[0] + elem[1]))
+	}
+}
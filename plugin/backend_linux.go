@@ -39,6 +39,9 @@ func (pm *Manager) Disable(refOrID string, config *types.PluginDisableConfig) er
 	if err != nil {
 		return err
 	}
+	pm.locks.Lock(p.GetID())
+	defer pm.locks.Unlock(p.GetID())
+
 	pm.mu.RLock()
 	c := pm.cMap[p]
 	pm.mu.RUnlock()
@@ -60,6 +63,8 @@ func (pm *Manager) Enable(refOrID string, config *types.PluginEnableConfig) erro
 	if err != nil {
 		return err
 	}
+	pm.locks.Lock(p.GetID())
+	defer pm.locks.Unlock(p.GetID())
 
 	c := &controller{timeoutInSecs: config.Timeout}
 	if err := pm.enable(p, c, false); err != nil {
@@ -132,51 +137,6 @@ func (s *tempConfigStore) RootFSFromConfig(c []byte) (*image.RootFS, error) {
 	return configToRootFS(c)
 }
 
-func computePrivileges(c types.PluginConfig) (types.PluginPrivileges, error) {
-	var privileges types.PluginPrivileges
-	if c.Network.Type != "null" && c.Network.Type != "bridge" && c.Network.Type != "" {
-		privileges = append(privileges, types.PluginPrivilege{
-			Name:        "network",
-			Description: "permissions to access a network",
-			Value:       []string{c.Network.Type},
-		})
-	}
-	for _, mount := range c.Mounts {
-		if mount.Source != nil {
-			privileges = append(privileges, types.PluginPrivilege{
-				Name:        "mount",
-				Description: "host path to mount",
-				Value:       []string{*mount.Source},
-			})
-		}
-	}
-	for _, device := range c.Linux.Devices {
-		if device.Path != nil {
-			privileges = append(privileges, types.PluginPrivilege{
-				Name:        "device",
-				Description: "host device to access",
-				Value:       []string{*device.Path},
-			})
-		}
-	}
-	if c.Linux.DeviceCreation {
-		privileges = append(privileges, types.PluginPrivilege{
-			Name:        "device-creation",
-			Description: "allow creating devices inside plugin",
-			Value:       []string{"true"},
-		})
-	}
-	if len(c.Linux.Capabilities) > 0 {
-		privileges = append(privileges, types.PluginPrivilege{
-			Name:        "capabilities",
-			Description: "list of additional capabilities required",
-			Value:       c.Linux.Capabilities,
-		})
-	}
-
-	return privileges, nil
-}
-
 // Privileges pulls a plugin config and computes the privileges required to install it.
 func (pm *Manager) Privileges(ctx context.Context, ref reference.Named, metaHeader http.Header, authConfig *types.AuthConfig) (types.PluginPrivileges, error) {
 	// create image store instance
@@ -206,7 +166,7 @@ func (pm *Manager) Privileges(ctx context.Context, ref reference.Named, metaHead
 		return nil, err
 	}
 
-	return computePrivileges(config)
+	return config.Privileges(), nil
 }
 
 // Pull pulls a plugin, check if the correct privileges are provided and install the plugin.
@@ -258,6 +218,114 @@ func (pm *Manager) Pull(ctx context.Context, ref reference.Named, name string, m
 	return nil
 }
 
+// Upgrade pulls a new version of a plugin and replaces the installed one with
+// it, keeping the name, ID and settings of the plugin. A plugin in use, for
+// example by volumes or containers, is only upgraded if force is set. An
+// enabled plugin is disabled during the upgrade and enabled again afterwards.
+// If the new version fails to enable, the previous version is restored.
+func (pm *Manager) Upgrade(ctx context.Context, ref reference.Named, name string, metaHeader http.Header, authConfig *types.AuthConfig, privileges types.PluginPrivileges, force bool, outStream io.Writer) (err error) {
+	p, err := pm.config.Store.GetV2Plugin(name)
+	if err != nil {
+		return err
+	}
+
+	pm.muGC.RLock()
+	defer pm.muGC.RUnlock()
+
+	tmpRootFSDir, err := ioutil.TempDir(pm.tmpDir(), ".rootfs")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpRootFSDir)
+
+	dm := &downloadManager{
+		tmpDir:    tmpRootFSDir,
+		blobStore: pm.blobStore,
+	}
+
+	pluginPullConfig := &distribution.ImagePullConfig{
+		Config: distribution.Config{
+			MetaHeaders:      metaHeader,
+			AuthConfig:       authConfig,
+			RegistryService:  pm.config.RegistryService,
+			ImageEventLogger: pm.config.LogPluginEvent,
+			ImageStore:       dm,
+		},
+		DownloadManager: dm,
+		Schema2Types:    distribution.PluginTypes,
+	}
+
+	defer func() {
+		go pm.GC()
+	}()
+
+	if err := pm.pull(ctx, ref, pluginPullConfig, outStream); err != nil {
+		return err
+	}
+
+	config, err := pm.setupNewConfig(dm.configDigest, &privileges)
+	if err != nil {
+		return err
+	}
+
+	pm.locks.Lock(p.GetID())
+	defer pm.locks.Unlock(p.GetID())
+
+	// the plugin may have been removed while the new version was pulled
+	if current, err := pm.config.Store.GetV2Plugin(p.GetID()); err != nil || current != p {
+		return fmt.Errorf("plugin %s was removed during the upgrade", name)
+	}
+	if !force && p.GetRefCount() > 0 {
+		return fmt.Errorf("plugin %s is in use", p.Name())
+	}
+
+	wasEnabled := p.IsEnabled()
+	var timeout int
+	if wasEnabled {
+		pm.mu.RLock()
+		c := pm.cMap[p]
+		pm.mu.RUnlock()
+		timeout = c.timeoutInSecs
+		if err := pm.disable(p, c); err != nil {
+			return err
+		}
+		pm.config.LogPluginEvent(p.GetID(), name, "disable")
+	}
+	enable := func() error {
+		if err := pm.enable(p, &controller{timeoutInSecs: timeout}, false); err != nil {
+			return err
+		}
+		pm.config.LogPluginEvent(p.GetID(), name, "enable")
+		return nil
+	}
+
+	commit, rollback, err := pm.upgradePlugin(p, config, dm.configDigest, dm.blobs, tmpRootFSDir)
+	if err != nil {
+		if wasEnabled {
+			if eerr := enable(); eerr != nil {
+				logrus.Errorf("failed to enable plugin %s after a failed upgrade: %v", p.Name(), eerr)
+			}
+		}
+		return err
+	}
+
+	if wasEnabled {
+		if err := enable(); err != nil {
+			if rerr := rollback(); rerr != nil {
+				return errors.Wrapf(err, "failed to enable the upgraded plugin, and failed to restore the previous version: %v", rerr)
+			}
+			if eerr := enable(); eerr != nil {
+				return errors.Wrapf(err, "failed to enable the upgraded plugin, the previous version was restored but failed to enable: %v", eerr)
+			}
+			return errors.Wrap(err, "failed to enable the upgraded plugin, the previous version was restored")
+		}
+	}
+	commit()
+
+	pm.config.LogPluginEvent(p.GetID(), name, "upgrade")
+	return nil
+}
+
 // List displays the list of plugins and associated metadata.
 func (pm *Manager) List() ([]types.Plugin, error) {
 	plugins := pm.config.Store.GetAll()
@@ -474,13 +542,15 @@ func (l *pluginLayer) Release() {
 // Remove deletes plugin's root directory.
 func (pm *Manager) Remove(name string, config *types.PluginRmConfig) error {
 	p, err := pm.config.Store.GetV2Plugin(name)
-	pm.mu.RLock()
-	c := pm.cMap[p]
-	pm.mu.RUnlock()
-
 	if err != nil {
 		return err
 	}
+	pm.locks.Lock(p.GetID())
+	defer pm.locks.Unlock(p.GetID())
+
+	pm.mu.RLock()
+	c := pm.cMap[p]
+	pm.mu.RUnlock()
 
 	if !config.ForceRemove {
 		if p.GetRefCount() > 0 {
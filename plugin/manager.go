@@ -18,6 +18,7 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/locker"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/plugin/v2"
 	"github.com/docker/docker/reference"
@@ -53,8 +54,9 @@ type ManagerConfig struct {
 // Manager controls the plugin subsystem.
 type Manager struct {
 	config           ManagerConfig
-	mu               sync.RWMutex // protects cMap
-	muGC             sync.RWMutex // protects blobstore deletions
+	mu               sync.RWMutex   // protects cMap
+	muGC             sync.RWMutex   // protects blobstore deletions
+	locks            *locker.Locker // serializes changes to a plugin, keyed by plugin ID
 	cMap             map[*v2.Plugin]*controller
 	containerdClient libcontainerd.Client
 	blobStore        *basicBlobStore
@@ -88,6 +90,7 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	}
 	manager := &Manager{
 		config: config,
+		locks:  locker.New(),
 	}
 	if err := os.MkdirAll(manager.config.Root, 0700); err != nil {
 		return nil, errors.Wrapf(err, "failed to mkdir %v", manager.config.Root)
@@ -149,36 +149,42 @@ func (pm *Manager) Shutdown() {
 	}
 }
 
-// createPlugin creates a new plugin. take lock before calling.
-func (pm *Manager) createPlugin(name string, configDigest digest.Digest, blobsums []digest.Digest, rootFSDir string, privileges *types.PluginPrivileges) (p *v2.Plugin, err error) {
-	if err := pm.config.Store.validateName(name); err != nil { // todo: this check is wrong. remove store
-		return nil, err
-	}
-
+// setupNewConfig reads the config of a pulled plugin from the blobstore and
+// validates it against the privileges granted by the user, if any.
+func (pm *Manager) setupNewConfig(configDigest digest.Digest, privileges *types.PluginPrivileges) (types.PluginConfig, error) {
 	configRC, err := pm.blobStore.Get(configDigest)
 	if err != nil {
-		return nil, err
+		return types.PluginConfig{}, err
 	}
 	defer configRC.Close()
 
 	var config types.PluginConfig
 	dec := json.NewDecoder(configRC)
 	if err := dec.Decode(&config); err != nil {
-		return nil, errors.Wrapf(err, "failed to parse config")
+		return types.PluginConfig{}, errors.Wrapf(err, "failed to parse config")
 	}
 	if dec.More() {
-		return nil, errors.New("invalid config json")
+		return types.PluginConfig{}, errors.New("invalid config json")
 	}
 
-	requiredPrivileges, err := computePrivileges(config)
-	if err != nil {
-		return nil, err
-	}
 	if privileges != nil {
-		if err := validatePrivileges(requiredPrivileges, *privileges); err != nil {
-			return nil, err
+		if err := validatePrivileges(config.Privileges(), *privileges); err != nil {
+			return types.PluginConfig{}, err
 		}
 	}
+	return config, nil
+}
+
+// createPlugin creates a new plugin. take lock before calling.
+func (pm *Manager) createPlugin(name string, configDigest digest.Digest, blobsums []digest.Digest, rootFSDir string, privileges *types.PluginPrivileges) (p *v2.Plugin, err error) {
+	if err := pm.config.Store.validateName(name); err != nil { // todo: this check is wrong. remove store
+		return nil, err
+	}
+
+	config, err := pm.setupNewConfig(configDigest, privileges)
+	if err != nil {
+		return nil, err
+	}
 
 	p = &v2.Plugin{
 		PluginObj: types.Plugin{
@@ -214,3 +220,74 @@ func (pm *Manager) createPlugin(name string, configDigest digest.Digest, blobsum
 
 	return p, nil
 }
+
+// upgradePlugin replaces the config, blobs and rootfs of the disabled plugin p
+// with the pulled ones, keeping its name, ID and the settings that the new
+// config still declares. The previous rootfs is kept aside until either
+// commit or rollback is called. The caller must hold the lock of p in
+// pm.locks.
+func (pm *Manager) upgradePlugin(p *v2.Plugin, config types.PluginConfig, configDigest digest.Digest, blobsums []digest.Digest, rootFSDir string) (commit func(), rollback func() error, err error) {
+	upgraded := &v2.Plugin{
+		PluginObj: types.Plugin{
+			Config: config,
+		},
+	}
+	upgraded.InitEmptySettings()
+	upgraded.InheritSettings(p)
+
+	pdir := filepath.Join(pm.config.Root, p.GetID())
+	orig := filepath.Join(pdir, rootFSFileName)
+	backup := orig + "-old"
+	if err := os.RemoveAll(backup); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to remove stale rootfs backup")
+	}
+	if err := os.Rename(orig, backup); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to back up rootfs")
+	}
+	if err := os.Rename(rootFSDir, orig); err != nil {
+		if rerr := os.Rename(backup, orig); rerr != nil {
+			logrus.Errorf("failed to restore rootfs of plugin %s: %v", p.Name(), rerr)
+		}
+		return nil, nil, errors.Wrap(err, "failed to rename rootfs")
+	}
+
+	previous, err := pm.config.Store.swap(p, pluginVersion{
+		config:       upgraded.PluginObj.Config,
+		settings:     upgraded.PluginObj.Settings,
+		configDigest: configDigest,
+		blobsums:     blobsums,
+	})
+	if err != nil {
+		os.RemoveAll(orig)
+		if rerr := os.Rename(backup, orig); rerr != nil {
+			logrus.Errorf("failed to restore rootfs of plugin %s: %v", p.Name(), rerr)
+		}
+		return nil, nil, err
+	}
+
+	rollback = func() error {
+		if _, err := pm.config.Store.swap(p, previous); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(orig); err != nil {
+			return errors.Wrap(err, "failed to remove upgraded rootfs")
+		}
+		if err := os.Rename(backup, orig); err != nil {
+			return errors.Wrap(err, "failed to restore rootfs")
+		}
+		return pm.save(p)
+	}
+	commit = func() {
+		if err := os.RemoveAll(backup); err != nil {
+			logrus.Warnf("unable to remove %q after plugin upgrade: %v", backup, err)
+		}
+	}
+
+	if err := pm.save(p); err != nil {
+		if rerr := rollback(); rerr != nil {
+			logrus.Errorf("failed to restore plugin %s: %v", p.Name(), rerr)
+		}
+		return nil, nil, err
+	}
+	return commit, rollback, nil
+}
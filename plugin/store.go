@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/pkg/plugingetter"
 	"github.com/docker/docker/pkg/plugins"
 	"github.com/docker/docker/plugin/v2"
@@ -101,6 +103,36 @@ func (ps *Store) SetState(p *v2.Plugin, state bool) {
 	p.PluginObj.Enabled = state
 }
 
+// pluginVersion is the part of a plugin that is replaced when it is upgraded.
+type pluginVersion struct {
+	config       types.PluginConfig
+	settings     types.PluginSettings
+	configDigest digest.Digest
+	blobsums     []digest.Digest
+}
+
+// swap replaces the version of a disabled plugin and returns the version it
+// replaced, so that the upgrade can be rolled back.
+func (ps *Store) swap(p *v2.Plugin, v pluginVersion) (pluginVersion, error) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if p.PluginObj.Enabled {
+		return pluginVersion{}, fmt.Errorf("plugin %s must be disabled to be upgraded", p.Name())
+	}
+	previous := pluginVersion{
+		config:       p.PluginObj.Config,
+		settings:     p.PluginObj.Settings,
+		configDigest: p.Config,
+		blobsums:     p.Blobsums,
+	}
+	p.PluginObj.Config = v.config
+	p.PluginObj.Settings = v.settings
+	p.Config = v.configDigest
+	p.Blobsums = v.blobsums
+	return previous, nil
+}
+
 // Add adds a plugin to memory and plugindb.
 // An error will be returned if there is a collision.
 func (ps *Store) Add(p *v2.Plugin) error {
@@ -31,3 +31,37 @@ func TestFilterByCapPos(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 }
+
+func TestStoreSwap(t *testing.T) {
+	s := NewStore("")
+	p := &v2.Plugin{PluginObj: types.Plugin{Name: "test:latest", ID: "1234"}, Config: "sha256:old"}
+	p.PluginObj.Settings.Env = []string{"DEBUG=1"}
+	s.Add(p)
+
+	previous, err := s.swap(p, pluginVersion{
+		config:       types.PluginConfig{Description: "new"},
+		settings:     types.PluginSettings{Env: []string{"DEBUG=2"}},
+		configDigest: "sha256:new",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name() != "test:latest" || p.GetID() != "1234" {
+		t.Fatalf("expected the name and ID to be kept, got %s %s", p.Name(), p.GetID())
+	}
+	if p.Config != "sha256:new" || p.PluginObj.Config.Description != "new" || p.PluginObj.Settings.Env[0] != "DEBUG=2" {
+		t.Fatalf("expected the new version, got %+v", p.PluginObj)
+	}
+
+	if _, err := s.swap(p, previous); err != nil {
+		t.Fatal(err)
+	}
+	if p.Config != "sha256:old" || p.PluginObj.Settings.Env[0] != "DEBUG=1" {
+		t.Fatalf("expected the previous version to be restored, got %+v", p.PluginObj)
+	}
+
+	s.SetState(p, true)
+	if _, err := s.swap(p, previous); err == nil {
+		t.Fatal("expected an error swapping an enabled plugin")
+	}
+}
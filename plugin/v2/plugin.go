@@ -182,6 +182,56 @@ next:
 	return nil
 }
 
+// InheritSettings applies the settings of old, a previous version of the
+// plugin, that may have been changed by the user and are still settable in
+// the config of p. Settings that p no longer declares are dropped.
+func (p *Plugin) InheritSettings(old *Plugin) {
+	var sets []string
+
+	defaults := make(map[string]*string, len(old.PluginObj.Config.Env))
+	for _, env := range old.PluginObj.Config.Env {
+		defaults[env.Name] = env.Value
+	}
+	for _, env := range old.PluginObj.Settings.Env {
+		parts := strings.SplitN(env, "=", 2)
+		if def, ok := defaults[parts[0]]; ok && def != nil && len(parts) == 2 && *def == parts[1] {
+			continue
+		}
+		sets = append(sets, env)
+	}
+
+	newMounts := make(map[string]bool, len(p.PluginObj.Config.Mounts))
+	for _, mount := range p.PluginObj.Config.Mounts {
+		newMounts[mount.Name] = mount.Source != nil
+	}
+	for _, mount := range old.PluginObj.Config.Mounts {
+		if mount.Source != nil && newMounts[mount.Name] && len(mount.Settable) > 0 {
+			sets = append(sets, fmt.Sprintf("%s.source=%s", mount.Name, *mount.Source))
+		}
+	}
+
+	newDevices := make(map[string]bool, len(p.PluginObj.Config.Linux.Devices))
+	for _, device := range p.PluginObj.Config.Linux.Devices {
+		newDevices[device.Name] = device.Path != nil
+	}
+	for _, device := range old.PluginObj.Config.Linux.Devices {
+		if device.Path != nil && newDevices[device.Name] && len(device.Settable) > 0 {
+			sets = append(sets, fmt.Sprintf("%s.path=%s", device.Name, *device.Path))
+		}
+	}
+
+	oldArgs := old.PluginObj.Config.Args
+	if oldArgs.Name != "" && strings.Join(old.PluginObj.Settings.Args, " ") != strings.Join(oldArgs.Value, " ") {
+		sets = append(sets, fmt.Sprintf("%s.value=%s", oldArgs.Name, strings.Join(old.PluginObj.Settings.Args, " ")))
+	}
+
+	for _, set := range sets {
+		// settings that are no longer declared or settable keep the default
+		// of the new version
+		p.Set([]string{set})
+	}
+}
+
 // IsEnabled returns the active state of the plugin.
 func (p *Plugin) IsEnabled() bool {
 	p.mu.RLock()
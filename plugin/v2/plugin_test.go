@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestInheritSettings(t *testing.T) {
+	old := &Plugin{PluginObj: types.Plugin{Config: types.PluginConfig{
+		Env: []types.PluginEnv{
+			{Name: "DEBUG", Value: strPtr("0"), Settable: []string{"value"}},
+			{Name: "LEVEL", Value: strPtr("info"), Settable: []string{"value"}},
+			{Name: "REMOVED", Value: strPtr("a"), Settable: []string{"value"}},
+			{Name: "FIXED", Value: strPtr("a"), Settable: []string{"value"}},
+		},
+		Mounts: []types.PluginMount{
+			{Name: "data", Source: strPtr("/var/lib/data"), Settable: []string{"source"}},
+		},
+		Args: types.PluginConfigArgs{Name: "args", Settable: []string{"value"}, Value: []string{"--a"}},
+	}}}
+	old.InitEmptySettings()
+	if err := old.Set([]string{"DEBUG=1", "REMOVED=b", "FIXED=b", "data.source=/srv/data", "args=--b --c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Plugin{PluginObj: types.Plugin{Config: types.PluginConfig{
+		Env: []types.PluginEnv{
+			{Name: "DEBUG", Value: strPtr("0"), Settable: []string{"value"}},
+			{Name: "LEVEL", Value: strPtr("warn"), Settable: []string{"value"}},
+			{Name: "FIXED", Value: strPtr("c")},
+		},
+		Mounts: []types.PluginMount{
+			{Name: "data", Source: strPtr("/var/lib/data"), Settable: []string{"source"}},
+		},
+		Args: types.PluginConfigArgs{Name: "args", Settable: []string{"value"}, Value: []string{"--a"}},
+	}}}
+	p.InitEmptySettings()
+	p.InheritSettings(old)
+
+	// DEBUG was set, LEVEL keeps the new default, REMOVED is no longer
+	// declared and FIXED is no longer settable.
+	if expected := []string{"DEBUG=1", "LEVEL=warn", "FIXED=c"}; !reflect.DeepEqual(p.PluginObj.Settings.Env, expected) {
+		t.Fatalf("expected env %v, got %v", expected, p.PluginObj.Settings.Env)
+	}
+	if source := *p.PluginObj.Config.Mounts[0].Source; source != "/srv/data" {
+		t.Fatalf("expected mount source /srv/data, got %s", source)
+	}
+	if expected := []string{"--b", "--c"}; !reflect.DeepEqual(p.PluginObj.Settings.Args, expected) {
+		t.Fatalf("expected args %v, got %v", expected, p.PluginObj.Settings.Args)
+	}
+}
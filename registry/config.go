@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"sort"
 	"strings"
 
 	registrytypes "github.com/docker/docker/api/types/registry"
@@ -21,12 +22,30 @@ type ServiceOptions struct {
 	// V2Only controls access to legacy registries.  If it is set to true via the
 	// command line flag the daemon will not attempt to contact v1 legacy registries
 	V2Only bool `json:"disable-legacy-registry,omitempty"`
+
+	// HostMirrors maps the hostname of a registry to the mirrors to pull
+	// its images from, in order of preference.
+	HostMirrors map[string][]MirrorConfig `json:"registry-host-mirrors,omitempty"`
+}
+
+// MirrorConfig holds the configuration of a mirror of a registry.
+type MirrorConfig struct {
+	// URL is the endpoint of the mirror, such as "https://mirror.example.com:5000".
+	URL string `json:"url"`
+	// Insecure disables the verification of the TLS certificate of the mirror.
+	Insecure bool `json:"insecure,omitempty"`
+	// CertsDir is the directory holding the CA (.crt) and client (.cert and
+	// .key) certificates to use with the mirror. It defaults to the directory
+	// of the mirror's host in CertsDir.
+	CertsDir string `json:"certs-dir,omitempty"`
 }
 
 // serviceConfig holds daemon configuration for the registry service.
 type serviceConfig struct {
 	registrytypes.ServiceConfig
 	V2Only bool
+
+	hostMirrors map[string][]MirrorConfig
 }
 
 var (
@@ -69,9 +88,11 @@ var lookupIP = net.LookupIP
 func (options *ServiceOptions) InstallCliFlags(flags *pflag.FlagSet) {
 	mirrors := opts.NewNamedListOptsRef("registry-mirrors", &options.Mirrors, ValidateMirror)
 	insecureRegistries := opts.NewNamedListOptsRef("insecure-registries", &options.InsecureRegistries, ValidateIndexName)
+	hostMirrors := &hostMirrorsOpt{values: &options.HostMirrors}
 
 	flags.Var(mirrors, "registry-mirror", "Preferred Docker registry mirror")
 	flags.Var(insecureRegistries, "insecure-registry", "Enable insecure registry communication")
+	flags.Var(hostMirrors, "registry-host-mirror", "Mirror to pull the images of a registry from (host=url)")
 
 	options.installCliPlatformFlags(flags)
 }
@@ -90,6 +111,7 @@ func newServiceConfig(options ServiceOptions) *serviceConfig {
 	}
 
 	config.LoadInsecureRegistries(options.InsecureRegistries)
+	config.LoadHostMirrors(options.HostMirrors)
 
 	return config
 }
@@ -155,6 +177,147 @@ skip:
 	return nil
 }
 
+// LoadMirrors loads mirrors to config, after removing duplicates. It returns
+// an error, and leaves config unchanged, if any of the mirrors is invalid.
+func (config *serviceConfig) LoadMirrors(mirrors []string) error {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, mirror := range mirrors {
+		m, err := ValidateMirror(mirror)
+		if err != nil {
+			return err
+		}
+		if !seen[m] {
+			seen[m] = true
+			unique = append(unique, m)
+		}
+	}
+
+	config.Mirrors = unique
+
+	// Configure public registry, since its mirrors changed.
+	config.IndexConfigs[IndexName] = &registrytypes.IndexInfo{
+		Name:     IndexName,
+		Mirrors:  config.Mirrors,
+		Secure:   true,
+		Official: true,
+	}
+
+	return nil
+}
+
+// LoadHostMirrors loads the mirrors of registries to config. It returns an
+// error, and leaves config unchanged, if any of the registries or mirrors is
+// invalid.
+func (config *serviceConfig) LoadHostMirrors(hostMirrors map[string][]MirrorConfig) error {
+	normalized, err := normalizeHostMirrors(hostMirrors)
+	if err != nil {
+		return err
+	}
+
+	config.hostMirrors = normalized
+	config.HostMirrors = make(map[string][]registrytypes.MirrorInfo, len(normalized))
+	for host, mirrors := range normalized {
+		infos := make([]registrytypes.MirrorInfo, 0, len(mirrors))
+		for _, mirror := range mirrors {
+			infos = append(infos, registrytypes.MirrorInfo{
+				URL:      mirror.URL,
+				Insecure: mirror.Insecure,
+			})
+		}
+		config.HostMirrors[host] = infos
+	}
+
+	return nil
+}
+
+// ValidateHostMirrors validates the mirrors of registries, keyed by the
+// hostname of the registry.
+func ValidateHostMirrors(hostMirrors map[string][]MirrorConfig) error {
+	_, err := normalizeHostMirrors(hostMirrors)
+	return err
+}
+
+// normalizeHostMirrors validates hostMirrors, and returns a copy of it with
+// normalized registry names and mirror URLs, and without duplicate mirrors.
+func normalizeHostMirrors(hostMirrors map[string][]MirrorConfig) (map[string][]MirrorConfig, error) {
+	normalized := make(map[string][]MirrorConfig, len(hostMirrors))
+	for host, mirrors := range hostMirrors {
+		if host == "" || strings.Contains(host, "/") {
+			return nil, fmt.Errorf("invalid registry host for mirrors: %q", host)
+		}
+		name, err := ValidateIndexName(host)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := normalized[name]; exists {
+			return nil, fmt.Errorf("mirrors of registry %s are configured more than once", name)
+		}
+
+		seen := make(map[string]bool)
+		unique := []MirrorConfig{}
+		for _, mirror := range mirrors {
+			u, err := ValidateMirror(mirror.URL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid mirror of registry %s: %v", name, err)
+			}
+			if seen[u] {
+				continue
+			}
+			seen[u] = true
+			mirror.URL = u
+			unique = append(unique, mirror)
+		}
+		normalized[name] = unique
+	}
+	return normalized, nil
+}
+
+// hostMirrorsOpt is the command line option of the mirrors of registries.
+// Each value is a host=url pair, which appends url to the mirrors of host.
+type hostMirrorsOpt struct {
+	values *map[string][]MirrorConfig
+}
+
+// Name returns the name of the option in the configuration file.
+func (o *hostMirrorsOpt) Name() string {
+	return "registry-host-mirrors"
+}
+
+// Set validates a host=url pair and adds it to the mirrors.
+func (o *hostMirrorsOpt) Set(val string) error {
+	parts := strings.SplitN(val, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("invalid registry host mirror: %s, expected host=url", val)
+	}
+	if _, err := ValidateMirror(parts[1]); err != nil {
+		return err
+	}
+
+	if *o.values == nil {
+		*o.values = make(map[string][]MirrorConfig)
+	}
+	(*o.values)[parts[0]] = append((*o.values)[parts[0]], MirrorConfig{URL: parts[1]})
+	return nil
+}
+
+// String returns the mirrors as a list of host=url pairs.
+func (o *hostMirrorsOpt) String() string {
+	var out []string
+	for host, mirrors := range *o.values {
+		for _, mirror := range mirrors {
+			out = append(out, host+"="+mirror.URL)
+		}
+	}
+	sort.Strings(out)
+	return fmt.Sprintf("%v", out)
+}
+
+// Type returns the type of the option.
+func (o *hostMirrorsOpt) Type() string {
+	return "host-mirror"
+}
+
 // isSecureIndex returns false if the provided indexName is part of the list of insecure registries
 // Insecure registries accept HTTP and/or accept HTTPS with certificates from unknown CAs.
 //
@@ -47,3 +47,94 @@ func TestValidateMirror(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadMirrors(t *testing.T) {
+	config := newServiceConfig(ServiceOptions{})
+
+	err := config.LoadMirrors([]string{"https://mirror-1.com", "https://mirror-2.com", "https://mirror-1.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"https://mirror-1.com/", "https://mirror-2.com/"}
+	if len(config.Mirrors) != len(expected) {
+		t.Fatalf("expected mirrors %v, got %v", expected, config.Mirrors)
+	}
+	for i := range expected {
+		if config.Mirrors[i] != expected[i] {
+			t.Fatalf("expected mirrors %v, got %v", expected, config.Mirrors)
+		}
+	}
+	if len(config.IndexConfigs[IndexName].Mirrors) != len(expected) {
+		t.Fatalf("expected index mirrors %v, got %v", expected, config.IndexConfigs[IndexName].Mirrors)
+	}
+
+	if err := config.LoadMirrors([]string{"https://mirror-3.com", "ftp://invalid"}); err == nil {
+		t.Fatal("expected an error for an invalid mirror")
+	}
+	if len(config.Mirrors) != len(expected) {
+		t.Fatalf("expected mirrors to be unchanged after a failed load, got %v", config.Mirrors)
+	}
+}
+
+func TestLoadHostMirrors(t *testing.T) {
+	config := newServiceConfig(ServiceOptions{})
+
+	err := config.LoadHostMirrors(map[string][]MirrorConfig{
+		"index.docker.io": {{URL: "https://mirror-1.com"}},
+		"quay.io": {
+			{URL: "https://mirror-1.com"},
+			{URL: "http://mirror-2.com", Insecure: true},
+			{URL: "https://mirror-1.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := config.HostMirrors["docker.io"]; !ok {
+		t.Fatalf("expected index.docker.io to be normalized to docker.io, got %v", config.HostMirrors)
+	}
+	mirrors := config.HostMirrors["quay.io"]
+	if len(mirrors) != 2 || mirrors[0].URL != "https://mirror-1.com/" || mirrors[1].URL != "http://mirror-2.com/" || !mirrors[1].Insecure {
+		t.Fatalf("unexpected mirrors of quay.io: %v", mirrors)
+	}
+
+	invalid := []map[string][]MirrorConfig{
+		{"quay.io": {{URL: "ftp://mirror-1.com"}}},
+		{"quay.io": {{URL: "https://mirror-1.com/v2/"}}},
+		{"https://quay.io": {{URL: "https://mirror-1.com"}}},
+		{"-quay.io": {{URL: "https://mirror-1.com"}}},
+		{"docker.io": {}, "index.docker.io": {}},
+	}
+	for _, hostMirrors := range invalid {
+		if err := config.LoadHostMirrors(hostMirrors); err == nil {
+			t.Errorf("expected an error for %v", hostMirrors)
+		}
+	}
+	if len(config.HostMirrors["quay.io"]) != 2 {
+		t.Fatalf("expected mirrors to be unchanged after a failed load, got %v", config.HostMirrors)
+	}
+}
+
+func TestHostMirrorsOpt(t *testing.T) {
+	var hostMirrors map[string][]MirrorConfig
+	o := &hostMirrorsOpt{values: &hostMirrors}
+
+	for _, val := range []string{"quay.io=https://mirror-1.com", "quay.io=https://mirror-2.com", "gcr.io=http://mirror-3.com"} {
+		if err := o.Set(val); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(hostMirrors["quay.io"]) != 2 || hostMirrors["quay.io"][1].URL != "https://mirror-2.com" {
+		t.Fatalf("unexpected mirrors of quay.io: %v", hostMirrors["quay.io"])
+	}
+	if expected := "[gcr.io=http://mirror-3.com quay.io=https://mirror-1.com quay.io=https://mirror-2.com]"; o.String() != expected {
+		t.Fatalf("expected %s, got %s", expected, o.String())
+	}
+
+	for _, val := range []string{"quay.io", "=https://mirror-1.com", "quay.io=ftp://mirror-1.com"} {
+		if err := o.Set(val); err == nil {
+			t.Errorf("expected an error for %s", val)
+		}
+	}
+}
@@ -686,6 +686,59 @@ func TestMirrorEndpointLookup(t *testing.T) {
 	}
 }
 
+func TestHostMirrorEndpointLookup(t *testing.T) {
+	s := NewService(ServiceOptions{
+		V2Only: true,
+		HostMirrors: map[string][]MirrorConfig{
+			"quay.io": {
+				{URL: "https://mirror-1.local:5000"},
+				{URL: "https://mirror-2.local", Insecure: true},
+			},
+		},
+	})
+
+	pullAPIEndpoints, err := s.LookupPullEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"https://mirror-1.local:5000/", "https://mirror-2.local/", "https://quay.io"}
+	if len(pullAPIEndpoints) != len(expected) {
+		t.Fatalf("Expected %d pull endpoints, got %d", len(expected), len(pullAPIEndpoints))
+	}
+	for i, endpoint := range pullAPIEndpoints {
+		if endpoint.URL.String() != expected[i] {
+			t.Fatalf("Expected pull endpoint %d to be %s, got %s", i, expected[i], endpoint.URL)
+		}
+		if endpoint.Mirror != (i < 2) {
+			t.Fatalf("Expected pull endpoint %s to be a mirror: %t", endpoint.URL, i < 2)
+		}
+	}
+	if pullAPIEndpoints[0].TLSConfig.InsecureSkipVerify {
+		t.Fatal("Expected TLS verification of the secure mirror")
+	}
+	if !pullAPIEndpoints[1].TLSConfig.InsecureSkipVerify {
+		t.Fatal("Expected no TLS verification of the insecure mirror")
+	}
+
+	pushAPIEndpoints, err := s.LookupPushEndpoints("quay.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pushAPIEndpoints) != 1 || pushAPIEndpoints[0].URL.Host != "quay.io" {
+		t.Fatalf("Expected only quay.io as push endpoint, got %v", pushAPIEndpoints)
+	}
+
+	pullAPIEndpoints, err = s.LookupPullEndpoints("gcr.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, endpoint := range pullAPIEndpoints {
+		if endpoint.Mirror {
+			t.Fatalf("Expected no mirror for gcr.io, got %s", endpoint.URL)
+		}
+	}
+}
+
 func TestPushRegistryTag(t *testing.T) {
 	r := spawnTestRegistrySession(t)
 	repoRef, err := reference.ParseNamed(REPO)
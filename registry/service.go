@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/api/types"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/reference"
+	"github.com/docker/go-connections/tlsconfig"
 )
 
 const (
@@ -32,6 +33,8 @@ type Service interface {
 	ServiceConfig() *registrytypes.ServiceConfig
 	TLSConfig(hostname string) (*tls.Config, error)
 	LoadInsecureRegistries([]string) error
+	LoadMirrors([]string) error
+	LoadHostMirrors(map[string][]MirrorConfig) error
 }
 
 // DefaultService is a registry service. It tracks configuration data such as a list
@@ -70,6 +73,13 @@ func (s *DefaultService) ServiceConfig() *registrytypes.ServiceConfig {
 
 	servConfig.Mirrors = append(servConfig.Mirrors, s.config.ServiceConfig.Mirrors...)
 
+	if len(s.config.ServiceConfig.HostMirrors) > 0 {
+		servConfig.HostMirrors = make(map[string][]registrytypes.MirrorInfo, len(s.config.ServiceConfig.HostMirrors))
+		for host, mirrors := range s.config.ServiceConfig.HostMirrors {
+			servConfig.HostMirrors[host] = append([]registrytypes.MirrorInfo(nil), mirrors...)
+		}
+	}
+
 	return &servConfig
 }
 
@@ -81,6 +91,22 @@ func (s *DefaultService) LoadInsecureRegistries(registries []string) error {
 	return s.config.LoadInsecureRegistries(registries)
 }
 
+// LoadMirrors loads registry mirrors for Service
+func (s *DefaultService) LoadMirrors(mirrors []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config.LoadMirrors(mirrors)
+}
+
+// LoadHostMirrors loads the mirrors of registries for Service
+func (s *DefaultService) LoadHostMirrors(hostMirrors map[string][]MirrorConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config.LoadHostMirrors(hostMirrors)
+}
+
 // Auth contacts the public registry with the provided credentials,
 // and returns OK if authentication was successful.
 // It can be used to verify the validity of a client's credentials.
@@ -256,6 +282,23 @@ func (s *DefaultService) tlsConfigForMirror(mirrorURL *url.URL) (*tls.Config, er
 	return s.tlsConfig(mirrorURL.Host)
 }
 
+// tlsConfigForHostMirror constructs the client TLS configuration of a mirror
+// configured for a registry, honoring its insecure and certificates settings.
+func (s *DefaultService) tlsConfigForHostMirror(mirrorURL *url.URL, mirror MirrorConfig) (*tls.Config, error) {
+	if !mirror.Insecure && mirror.CertsDir == "" {
+		return s.tlsConfigForMirror(mirrorURL)
+	}
+
+	tlsConfig := tlsconfig.ServerDefault()
+	tlsConfig.InsecureSkipVerify = mirror.Insecure
+	if !mirror.Insecure {
+		if err := ReadCertsDirectory(tlsConfig, mirror.CertsDir); err != nil {
+			return nil, err
+		}
+	}
+	return tlsConfig, nil
+}
+
 // LookupPullEndpoints creates a list of endpoints to try to pull from, in order of preference.
 // It gives preference to v2 endpoints over v1, mirrors over the actual
 // registry, and HTTPS over plain HTTP.
@@ -32,6 +32,11 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 				TLSConfig:    mirrorTLSConfig,
 			})
 		}
+		hostMirrors, err := s.lookupHostMirrorEndpoints(IndexName)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, hostMirrors...)
 		// v2 registry
 		endpoints = append(endpoints, APIEndpoint{
 			URL:          DefaultV2Registry,
@@ -44,22 +49,26 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 		return endpoints, nil
 	}
 
+	// v2 mirrors
+	endpoints, err = s.lookupHostMirrorEndpoints(hostname)
+	if err != nil {
+		return nil, err
+	}
+
 	tlsConfig, err = s.tlsConfig(hostname)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoints = []APIEndpoint{
-		{
-			URL: &url.URL{
-				Scheme: "https",
-				Host:   hostname,
-			},
-			Version:      APIVersion2,
-			TrimHostname: true,
-			TLSConfig:    tlsConfig,
+	endpoints = append(endpoints, APIEndpoint{
+		URL: &url.URL{
+			Scheme: "https",
+			Host:   hostname,
 		},
-	}
+		Version:      APIVersion2,
+		TrimHostname: true,
+		TLSConfig:    tlsConfig,
+	})
 
 	if tlsConfig.InsecureSkipVerify {
 		endpoints = append(endpoints, APIEndpoint{
@@ -76,3 +85,27 @@ func (s *DefaultService) lookupV2Endpoints(hostname string) (endpoints []APIEndp
 
 	return endpoints, nil
 }
+
+// lookupHostMirrorEndpoints returns the endpoints of the mirrors configured
+// for the registry hostname, in order of preference.
+func (s *DefaultService) lookupHostMirrorEndpoints(hostname string) (endpoints []APIEndpoint, err error) {
+	for _, mirror := range s.config.hostMirrors[hostname] {
+		mirrorURL, err := url.Parse(mirror.URL)
+		if err != nil {
+			return nil, err
+		}
+		mirrorTLSConfig, err := s.tlsConfigForHostMirror(mirrorURL, mirror)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, APIEndpoint{
+			URL: mirrorURL,
+			// guess mirrors are v2
+			Version:      APIVersion2,
+			Mirror:       true,
+			TrimHostname: true,
+			TLSConfig:    mirrorTLSConfig,
+		})
+	}
+	return endpoints, nil
+}
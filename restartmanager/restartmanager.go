@@ -10,8 +10,11 @@ import (
 )
 
 const (
-	backoffMultiplier = 2
-	defaultTimeout    = 100 * time.Millisecond
+	backoffMultiplier      = 2
+	defaultTimeout         = 100 * time.Millisecond
+	defaultMaxTimeout      = 1 * time.Minute
+	defaultResetWindow     = 10 * time.Second
+	defaultCrashLoopWindow = 5 * time.Minute
 )
 
 // ErrRestartCanceled is returned when the restart manager has been
@@ -22,6 +25,8 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	CrashLooping() bool
+	ResetWindow() time.Duration
 }
 
 type restartManager struct {
@@ -33,6 +38,8 @@ type restartManager struct {
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
+	restartTimes []time.Time // times of the last restarts within the crash loop window
+	crashLooping bool
 }
 
 // New returns a new restartManager based on a policy.
@@ -65,16 +72,21 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, fmt.Errorf("invalid call on an active restart manager")
 	}
-	// if the container ran for more than 10s, regardless of status and policy reset the
-	// the timeout back to the default.
-	if executionDuration.Seconds() >= 10 {
+	// if the container ran for longer than the reset window, regardless of status and
+	// policy reset the timeout back to the initial delay, and forget about past crashes.
+	if executionDuration >= rm.resetWindow() {
 		rm.timeout = 0
+		rm.restartTimes = nil
+		rm.crashLooping = false
 	}
 	if rm.timeout == 0 {
-		rm.timeout = defaultTimeout
+		rm.timeout = rm.initialDelay()
 	} else {
 		rm.timeout *= backoffMultiplier
 	}
+	if maxDelay := rm.maxDelay(); rm.timeout > maxDelay {
+		rm.timeout = maxDelay
+	}
 
 	var restart bool
 	switch {
@@ -95,9 +107,11 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	}
 
 	rm.restartCount++
+	rm.recordRestart(time.Now())
 
 	unlockOnExit = false
 	rm.active = true
+	timeout := rm.timeout
 	rm.Unlock()
 
 	ch := make(chan error)
@@ -106,7 +120,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 		case <-rm.cancel:
 			ch <- ErrRestartCanceled
 			close(ch)
-		case <-time.After(rm.timeout):
+		case <-time.After(timeout):
 			rm.Lock()
 			close(ch)
 			rm.active = false
@@ -117,6 +131,94 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	return true, ch, nil
 }
 
+// CrashLooping returns whether the container restarted more than the crash
+// loop threshold of its policy within the crash loop window.
+func (rm *restartManager) CrashLooping() bool {
+	rm.Lock()
+	defer rm.Unlock()
+	return rm.crashLooping
+}
+
+// SetCrashLooping marks the container as being in a crash loop, when its
+// state is restored. The times of the restarts that led to the crash loop are
+// not known, so they are counted as if they happened now: the container stays
+// in the crash loop if it restarts again within the crash loop window.
+func (rm *restartManager) SetCrashLooping() {
+	rm.Lock()
+	defer rm.Unlock()
+	threshold := rm.policy.CrashLoopThreshold
+	if threshold <= 0 {
+		return
+	}
+	now := time.Now()
+	rm.restartTimes = make([]time.Time, 0, threshold+1)
+	for i := 0; i < threshold; i++ {
+		rm.restartTimes = append(rm.restartTimes, now)
+	}
+	rm.crashLooping = true
+}
+
+// recordRestart records a restart at time now, and updates the crash loop
+// detection accordingly.
+func (rm *restartManager) recordRestart(now time.Time) {
+	threshold := rm.policy.CrashLoopThreshold
+	if threshold <= 0 {
+		return
+	}
+
+	window := rm.policy.CrashLoopWindow
+	if window == 0 {
+		window = defaultCrashLoopWindow
+	}
+
+	restartTimes := rm.restartTimes[:0]
+	for _, t := range rm.restartTimes {
+		if now.Sub(t) < window {
+			restartTimes = append(restartTimes, t)
+		}
+	}
+	restartTimes = append(restartTimes, now)
+	// only the last threshold+1 restarts matter to tell whether there were
+	// more than threshold of them.
+	if len(restartTimes) > threshold+1 {
+		restartTimes = restartTimes[len(restartTimes)-threshold-1:]
+	}
+	rm.restartTimes = restartTimes
+	rm.crashLooping = len(restartTimes) > threshold
+}
+
+func (rm *restartManager) initialDelay() time.Duration {
+	if rm.policy.InitialDelay > 0 {
+		return rm.policy.InitialDelay
+	}
+	return defaultTimeout
+}
+
+func (rm *restartManager) maxDelay() time.Duration {
+	if rm.policy.MaxDelay > 0 {
+		return rm.policy.MaxDelay
+	}
+	if initialDelay := rm.initialDelay(); initialDelay > defaultMaxTimeout {
+		return initialDelay
+	}
+	return defaultMaxTimeout
+}
+
+// ResetWindow returns how long the container must run for its restart delay
+// to be reset, and for it to leave a crash loop.
+func (rm *restartManager) ResetWindow() time.Duration {
+	rm.Lock()
+	defer rm.Unlock()
+	return rm.resetWindow()
+}
+
+func (rm *restartManager) resetWindow() time.Duration {
+	if rm.policy.ResetWindow > 0 {
+		return rm.policy.ResetWindow
+	}
+	return defaultResetWindow
+}
+
 func (rm *restartManager) Cancel() error {
 	rm.Do(func() {
 		rm.Lock()
@@ -34,3 +34,101 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerCustomDelays(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     3 * time.Second,
+		ResetWindow:  time.Minute,
+	}
+	rm := New(policy, 0).(*restartManager)
+
+	for _, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if _, _, err := rm.ShouldRestart(0, false, 30*time.Second); err != nil {
+			t.Fatal(err)
+		}
+		if rm.timeout != expected {
+			t.Fatalf("restart manager should have a timeout of %s but has %s", expected, rm.timeout)
+		}
+		rm.active = false
+	}
+
+	if _, _, err := rm.ShouldRestart(0, false, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if rm.timeout != policy.InitialDelay {
+		t.Fatalf("restart manager should have a timeout of %s but has %s", policy.InitialDelay, rm.timeout)
+	}
+}
+
+func TestRestartManagerMaxTimeout(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "always"}, 0).(*restartManager)
+	rm.timeout = 50 * time.Second
+	if _, _, err := rm.ShouldRestart(0, false, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if rm.timeout != defaultMaxTimeout {
+		t.Fatalf("restart manager should have a timeout of %s but has %s", defaultMaxTimeout, rm.timeout)
+	}
+}
+
+func TestRestartManagerCrashLoop(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "always", CrashLoopThreshold: 2, CrashLoopWindow: time.Minute}, 0).(*restartManager)
+	now := time.Now()
+
+	rm.recordRestart(now.Add(-2 * time.Minute))
+	rm.recordRestart(now.Add(-30 * time.Second))
+	rm.recordRestart(now.Add(-20 * time.Second))
+	if rm.CrashLooping() {
+		t.Fatal("container should not be crash looping with 2 restarts within the window")
+	}
+
+	rm.recordRestart(now)
+	if !rm.CrashLooping() {
+		t.Fatal("container should be crash looping with 3 restarts within the window")
+	}
+	if len(rm.restartTimes) != 3 {
+		t.Fatalf("restart manager should keep 3 restart times but has %d", len(rm.restartTimes))
+	}
+
+	// running for longer than the reset window ends the crash loop
+	if _, _, err := rm.ShouldRestart(0, false, 10*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if rm.CrashLooping() {
+		t.Fatal("container should not be crash looping after running for the reset window")
+	}
+}
+
+func TestRestartManagerSetCrashLooping(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "always", CrashLoopThreshold: 2, CrashLoopWindow: time.Minute}, 0).(*restartManager)
+	rm.SetCrashLooping()
+	if !rm.CrashLooping() {
+		t.Fatal("container should be crash looping once restored as such")
+	}
+
+	// restarting again within the window keeps the container in the crash loop
+	if _, _, err := rm.ShouldRestart(1, false, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if !rm.CrashLooping() {
+		t.Fatal("container should still be crash looping after restarting within the window")
+	}
+
+	rm = New(container.RestartPolicy{Name: "always"}, 0).(*restartManager)
+	rm.SetCrashLooping()
+	if rm.CrashLooping() {
+		t.Fatal("crash loop detection should be disabled without a threshold")
+	}
+}
+
+func TestRestartManagerCrashLoopDisabled(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "always"}, 0).(*restartManager)
+	for i := 0; i < 10; i++ {
+		rm.recordRestart(time.Now())
+	}
+	if rm.CrashLooping() {
+		t.Fatal("crash loop detection should be disabled without a threshold")
+	}
+}
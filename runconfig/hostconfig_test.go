@@ -167,11 +167,11 @@ func TestPidModeTest(t *testing.T) {
 func TestRestartPolicy(t *testing.T) {
 	restartPolicies := map[container.RestartPolicy][]bool{
 		// none, always, failure
-		container.RestartPolicy{}:                {true, false, false},
-		container.RestartPolicy{"something", 0}:  {false, false, false},
-		container.RestartPolicy{"no", 0}:         {true, false, false},
-		container.RestartPolicy{"always", 0}:     {false, true, false},
-		container.RestartPolicy{"on-failure", 0}: {false, false, true},
+		container.RestartPolicy{}:                   {true, false, false},
+		container.RestartPolicy{Name: "something"}:  {false, false, false},
+		container.RestartPolicy{Name: "no"}:         {true, false, false},
+		container.RestartPolicy{Name: "always"}:     {false, true, false},
+		container.RestartPolicy{Name: "on-failure"}: {false, false, true},
 	}
 	for restartPolicy, state := range restartPolicies {
 		if restartPolicy.IsNone() != state[0] {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -36,6 +37,7 @@ type ContainerOptions struct {
 	env                opts.ListOpts
 	labels             opts.ListOpts
 	devices            opts.ListOpts
+	deviceCgroupRules  opts.ListOpts
 	ulimits            *UlimitOpt
 	sysctls            *opts.MapOpts
 	publish            opts.ListOpts
@@ -92,6 +94,11 @@ type ContainerOptions struct {
 	ipcMode            string
 	pidsLimit          int64
 	restartPolicy      string
+	restartDelay       time.Duration
+	restartMaxDelay    time.Duration
+	restartReset       time.Duration
+	crashLoopThreshold int
+	crashLoopWindow    time.Duration
 	readonlyRootfs     bool
 	loggingDriver      string
 	cgroupParent       string
@@ -102,9 +109,13 @@ type ContainerOptions struct {
 	shmSize            string
 	noHealthcheck      bool
 	healthCmd          string
+	healthHTTP         string
+	healthHTTPStatus   string
+	healthTCP          string
 	healthInterval     time.Duration
 	healthTimeout      time.Duration
 	healthRetries      int
+	healthStartPeriod  time.Duration
 	runtime            string
 	autoRemove         bool
 	init               bool
@@ -131,6 +142,7 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 		deviceWriteBps:    NewThrottledeviceOpt(ValidateThrottleBpsDevice),
 		deviceWriteIOps:   NewThrottledeviceOpt(ValidateThrottleIOpsDevice),
 		devices:           opts.NewListOpts(ValidateDevice),
+		deviceCgroupRules: opts.NewListOpts(ValidateDeviceCgroupRule),
 		env:               opts.NewListOpts(ValidateEnv),
 		envFile:           opts.NewListOpts(nil),
 		expose:            opts.NewListOpts(nil),
@@ -154,6 +166,8 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	// General purpose flags
 	flags.VarP(&copts.attach, "attach", "a", "Attach to STDIN, STDOUT or STDERR")
 	flags.Var(&copts.devices, "device", "Add a host device to the container")
+	flags.Var(&copts.deviceCgroupRules, "device-cgroup-rule", "Add a rule to the cgroup allowed devices list")
+	flags.SetAnnotation("device-cgroup-rule", "version", []string{"1.26"})
 	flags.VarP(&copts.env, "env", "e", "Set environment variables")
 	flags.Var(&copts.envFile, "env-file", "Read in a file of environment variables")
 	flags.StringVar(&copts.entrypoint, "entrypoint", "", "Overwrite the default ENTRYPOINT of the image")
@@ -164,6 +178,16 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 	flags.Var(&copts.labelsFile, "label-file", "Read in a line delimited file of labels")
 	flags.BoolVar(&copts.readonlyRootfs, "read-only", false, "Mount the container's root filesystem as read only")
 	flags.StringVar(&copts.restartPolicy, "restart", "no", "Restart policy to apply when a container exits")
+	flags.DurationVar(&copts.restartDelay, "restart-delay", 0, "Delay before the first restart, doubled at each restart (ns|us|ms|s|m|h) (default 100ms)")
+	flags.SetAnnotation("restart-delay", "version", []string{"1.26"})
+	flags.DurationVar(&copts.restartMaxDelay, "restart-max-delay", 0, "Maximum delay between restarts (ns|us|ms|s|m|h) (default 1m)")
+	flags.SetAnnotation("restart-max-delay", "version", []string{"1.26"})
+	flags.DurationVar(&copts.restartReset, "restart-reset-window", 0, "Time the container has to run to reset the restart delay (ns|us|ms|s|m|h) (default 10s)")
+	flags.SetAnnotation("restart-reset-window", "version", []string{"1.26"})
+	flags.IntVar(&copts.crashLoopThreshold, "restart-crashloop-threshold", 0, "Number of restarts within the crash loop window above which the container is in a crash loop, 0 to disable")
+	flags.SetAnnotation("restart-crashloop-threshold", "version", []string{"1.26"})
+	flags.DurationVar(&copts.crashLoopWindow, "restart-crashloop-window", 0, "Window in which restarts count towards the crash loop threshold (ns|us|ms|s|m|h) (default 5m)")
+	flags.SetAnnotation("restart-crashloop-window", "version", []string{"1.26"})
 	flags.StringVar(&copts.stopSignal, "stop-signal", signal.DefaultStopSignal, fmt.Sprintf("Signal to stop a container, %v by default", signal.DefaultStopSignal))
 	flags.IntVar(&copts.stopTimeout, "stop-timeout", 0, "Timeout (in seconds) to stop a container")
 	flags.SetAnnotation("stop-timeout", "version", []string{"1.25"})
@@ -219,9 +243,17 @@ func AddFlags(flags *pflag.FlagSet) *ContainerOptions {
 
 	// Health-checking
 	flags.StringVar(&copts.healthCmd, "health-cmd", "", "Command to run to check health")
+	flags.StringVar(&copts.healthHTTP, "health-http", "", "URL to send an HTTP GET request to, to check health")
+	flags.SetAnnotation("health-http", "version", []string{"1.26"})
+	flags.StringVar(&copts.healthHTTPStatus, "health-http-status", "", "Range of HTTP status codes to consider healthy (default 200-399)")
+	flags.SetAnnotation("health-http-status", "version", []string{"1.26"})
+	flags.StringVar(&copts.healthTCP, "health-tcp", "", "Address to open a TCP connection to, to check health")
+	flags.SetAnnotation("health-tcp", "version", []string{"1.26"})
 	flags.DurationVar(&copts.healthInterval, "health-interval", 0, "Time between running the check (ns|us|ms|s|m|h) (default 0s)")
 	flags.IntVar(&copts.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy")
 	flags.DurationVar(&copts.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ns|us|ms|s|m|h) (default 0s)")
+	flags.DurationVar(&copts.healthStartPeriod, "health-start-period", 0, "Start period for the container to initialize before failing checks count towards retries (ns|us|ms|s|m|h) (default 0s)")
+	flags.SetAnnotation("health-start-period", "version", []string{"1.26"})
 	flags.BoolVar(&copts.noHealthcheck, "no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
 
 	// Resource management
@@ -470,6 +502,26 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if copts.restartDelay < 0 {
+		return nil, nil, nil, fmt.Errorf("--restart-delay cannot be negative")
+	}
+	if copts.restartMaxDelay < 0 {
+		return nil, nil, nil, fmt.Errorf("--restart-max-delay cannot be negative")
+	}
+	if copts.restartReset < 0 {
+		return nil, nil, nil, fmt.Errorf("--restart-reset-window cannot be negative")
+	}
+	if copts.crashLoopThreshold < 0 {
+		return nil, nil, nil, fmt.Errorf("--restart-crashloop-threshold cannot be negative")
+	}
+	if copts.crashLoopWindow < 0 {
+		return nil, nil, nil, fmt.Errorf("--restart-crashloop-window cannot be negative")
+	}
+	restartPolicy.InitialDelay = copts.restartDelay
+	restartPolicy.MaxDelay = copts.restartMaxDelay
+	restartPolicy.ResetWindow = copts.restartReset
+	restartPolicy.CrashLoopThreshold = copts.crashLoopThreshold
+	restartPolicy.CrashLoopWindow = copts.crashLoopWindow
 
 	loggingOpts, err := parseLoggingOpts(copts.loggingDriver, copts.loggingOpts.GetAll())
 	if err != nil {
@@ -489,8 +541,12 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 	// Healthcheck
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := copts.healthCmd != "" ||
+		copts.healthHTTP != "" ||
+		copts.healthHTTPStatus != "" ||
+		copts.healthTCP != "" ||
 		copts.healthInterval != 0 ||
 		copts.healthTimeout != 0 ||
+		copts.healthStartPeriod != 0 ||
 		copts.healthRetries != 0
 	if copts.noHealthcheck {
 		if haveHealthSettings {
@@ -499,10 +555,9 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		test := strslice.StrSlice{"NONE"}
 		healthConfig = &container.HealthConfig{Test: test}
 	} else if haveHealthSettings {
-		var probe strslice.StrSlice
-		if copts.healthCmd != "" {
-			args := []string{"CMD-SHELL", copts.healthCmd}
-			probe = strslice.StrSlice(args)
+		probe, err := HealthcheckTest(copts.healthCmd, copts.healthHTTP, copts.healthHTTPStatus, copts.healthTCP)
+		if err != nil {
+			return nil, nil, nil, err
 		}
 		if copts.healthInterval < 0 {
 			return nil, nil, nil, fmt.Errorf("--health-interval cannot be negative")
@@ -510,12 +565,16 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		if copts.healthTimeout < 0 {
 			return nil, nil, nil, fmt.Errorf("--health-timeout cannot be negative")
 		}
+		if copts.healthStartPeriod < 0 {
+			return nil, nil, nil, fmt.Errorf("--health-start-period cannot be negative")
+		}
 
 		healthConfig = &container.HealthConfig{
-			Test:     probe,
-			Interval: copts.healthInterval,
-			Timeout:  copts.healthTimeout,
-			Retries:  copts.healthRetries,
+			Test:        strslice.StrSlice(probe),
+			Interval:    copts.healthInterval,
+			Timeout:     copts.healthTimeout,
+			StartPeriod: copts.healthStartPeriod,
+			Retries:     copts.healthRetries,
 		}
 	}
 
@@ -548,6 +607,7 @@ func Parse(flags *pflag.FlagSet, copts *ContainerOptions) (*container.Config, *c
 		IOMaximumBandwidth:   uint64(maxIOBandwidth),
 		Ulimits:              copts.ulimits.GetList(),
 		Devices:              deviceMappings,
+		DeviceCgroupRules:    copts.deviceCgroupRules.GetAll(),
 	}
 
 	config := &container.Config{
@@ -835,6 +895,36 @@ func ParseDevice(device string) (container.DeviceMapping, error) {
 	return deviceMapping, nil
 }
 
+// HealthcheckTest returns the health check test for the --health-cmd,
+// --health-http, --health-http-status and --health-tcp options. It returns
+// nil if none of the probe options are set.
+func HealthcheckTest(cmd, httpURL, httpStatus, tcpAddr string) ([]string, error) {
+	probes := 0
+	for _, probe := range []string{cmd, httpURL, tcpAddr} {
+		if probe != "" {
+			probes++
+		}
+	}
+	if probes > 1 {
+		return nil, fmt.Errorf("--health-cmd, --health-http and --health-tcp are mutually exclusive")
+	}
+	if httpStatus != "" && httpURL == "" {
+		return nil, fmt.Errorf("--health-http-status requires --health-http")
+	}
+
+	switch {
+	case cmd != "":
+		return []string{"CMD-SHELL", cmd}, nil
+	case httpURL != "" && httpStatus != "":
+		return []string{"HTTP", httpURL, httpStatus}, nil
+	case httpURL != "":
+		return []string{"HTTP", httpURL}, nil
+	case tcpAddr != "":
+		return []string{"TCP", tcpAddr}, nil
+	}
+	return nil, nil
+}
+
 // ParseLink parses and validates the specified string as a link format (name:alias)
 func ParseLink(val string) (string, string, error) {
 	if val == "" {
@@ -883,6 +973,19 @@ func ValidDeviceMode(mode string) bool {
 	return true
 }
 
+var deviceCgroupRuleRegexp = regexp.MustCompile(`^([acb]) ([0-9]+|\*):([0-9]+|\*) ([rwm]{1,3})$`)
+
+// ValidateDeviceCgroupRule validates a device cgroup rule string format
+// It will make sure 'val' is in the form:
+//    'type major:minor mode'
+func ValidateDeviceCgroupRule(val string) (string, error) {
+	if deviceCgroupRuleRegexp.MatchString(val) {
+		return val, nil
+	}
+
+	return val, fmt.Errorf("invalid device cgroup format '%s'", val)
+}
+
 // ValidateDevice validates a path for devices
 // It will make sure 'val' is in the form:
 //    [host-dir:]container-path[:mode]
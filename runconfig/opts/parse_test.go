@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -586,6 +587,37 @@ func TestParseRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestParseRestartPolicyBackoff(t *testing.T) {
+	_, hostconfig, _, err := parseRun([]string{"--restart=always", "--restart-delay=1s", "--restart-max-delay=30s", "--restart-reset-window=1m", "--restart-crashloop-threshold=5", "--restart-crashloop-window=10m", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := container.RestartPolicy{
+		Name:               "always",
+		InitialDelay:       time.Second,
+		MaxDelay:           30 * time.Second,
+		ResetWindow:        time.Minute,
+		CrashLoopThreshold: 5,
+		CrashLoopWindow:    10 * time.Minute,
+	}
+	if hostconfig.RestartPolicy != expected {
+		t.Fatalf("Expected %v, got %v", expected, hostconfig.RestartPolicy)
+	}
+
+	invalids := map[string]string{
+		"--restart-delay=-1s":              "--restart-delay cannot be negative",
+		"--restart-max-delay=-1s":          "--restart-max-delay cannot be negative",
+		"--restart-reset-window=-1s":       "--restart-reset-window cannot be negative",
+		"--restart-crashloop-threshold=-1": "--restart-crashloop-threshold cannot be negative",
+		"--restart-crashloop-window=-1s":   "--restart-crashloop-window cannot be negative",
+	}
+	for flag, expectedError := range invalids {
+		if _, _, _, err := parseRun([]string{"--restart=always", flag, "img", "cmd"}); err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected an error with message '%v' for %v, got %v", expectedError, flag, err)
+		}
+	}
+}
+
 func TestParseHealth(t *testing.T) {
 	checkOk := func(args ...string) *container.HealthConfig {
 		config, _, _, err := parseRun(args)
@@ -619,10 +651,33 @@ func TestParseHealth(t *testing.T) {
 	checkError("--no-healthcheck conflicts with --health-* options",
 		"--no-healthcheck", "--health-cmd=/check.sh -q", "img", "cmd")
 
-	health = checkOk("--health-timeout=2s", "--health-retries=3", "--health-interval=4.5s", "img", "cmd")
-	if health.Timeout != 2*time.Second || health.Retries != 3 || health.Interval != 4500*time.Millisecond {
+	health = checkOk("--health-http=http://localhost:8080/health", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "HTTP" || health.Test[1] != "http://localhost:8080/health" {
+		t.Fatalf("--health-http: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-http=http://localhost/", "--health-http-status=200-299", "img", "cmd")
+	if len(health.Test) != 3 || health.Test[0] != "HTTP" || health.Test[2] != "200-299" {
+		t.Fatalf("--health-http-status: got %#v", health.Test)
+	}
+
+	health = checkOk("--health-tcp=localhost:5432", "img", "cmd")
+	if len(health.Test) != 2 || health.Test[0] != "TCP" || health.Test[1] != "localhost:5432" {
+		t.Fatalf("--health-tcp: got %#v", health.Test)
+	}
+
+	checkError("--health-cmd, --health-http and --health-tcp are mutually exclusive",
+		"--health-cmd=/check.sh", "--health-tcp=localhost:5432", "img", "cmd")
+	checkError("--health-http-status requires --health-http",
+		"--health-http-status=200", "img", "cmd")
+
+	health = checkOk("--health-timeout=2s", "--health-retries=3", "--health-interval=4.5s", "--health-start-period=5s", "img", "cmd")
+	if health.Timeout != 2*time.Second || health.Retries != 3 || health.Interval != 4500*time.Millisecond || health.StartPeriod != 5*time.Second {
 		t.Fatalf("--health-*: got %#v", health)
 	}
+
+	checkError("--health-start-period cannot be negative",
+		"--health-start-period=-1s", "img", "cmd")
 }
 
 func TestParseLoggingOpts(t *testing.T) {
@@ -841,6 +896,59 @@ func TestValidateDevice(t *testing.T) {
 	}
 }
 
+func TestValidateDeviceCgroupRule(t *testing.T) {
+	valid := []string{
+		"c 188:* rmw",
+		"c 1:3 r",
+		"b 8:0 rw",
+		"a *:* rwm",
+		"c *:128 m",
+	}
+	invalid := []string{
+		"",
+		"c",
+		"c 188 rmw",
+		"c 188:* ",
+		"c 188:* rwmx",
+		"d 188:* rwm",
+		"c 188:a rwm",
+		"c -1:2 rwm",
+		"c 188:*  rmw",
+	}
+
+	for _, rule := range valid {
+		if _, err := ValidateDeviceCgroupRule(rule); err != nil {
+			t.Fatalf("ValidateDeviceCgroupRule(%q) should succeed: error %q", rule, err)
+		}
+	}
+
+	for _, rule := range invalid {
+		_, err := ValidateDeviceCgroupRule(rule)
+		if err == nil {
+			t.Fatalf("ValidateDeviceCgroupRule(%q) should have failed validation", rule)
+		}
+		expectedError := fmt.Sprintf("invalid device cgroup format '%s'", rule)
+		if err.Error() != expectedError {
+			t.Fatalf("ValidateDeviceCgroupRule(%q) error should be %q, got %q", rule, expectedError, err.Error())
+		}
+	}
+}
+
+func TestParseDeviceCgroupRules(t *testing.T) {
+	_, hostconfig, _, err := parseRun([]string{"--device-cgroup-rule=c 188:* rmw", "--device-cgroup-rule=b 8:0 r", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"c 188:* rmw", "b 8:0 r"}
+	if !reflect.DeepEqual(hostconfig.DeviceCgroupRules, expected) {
+		t.Fatalf("Expected device cgroup rules %v, got %v", expected, hostconfig.DeviceCgroupRules)
+	}
+
+	if _, _, _, err := parseRun([]string{"--device-cgroup-rule=c 188 rmw", "img", "cmd"}); err == nil || !strings.Contains(err.Error(), "invalid device cgroup format 'c 188 rmw'") {
+		t.Fatalf("Expected an invalid device cgroup rule error, got %v", err)
+	}
+}
+
 func TestVolumeSplitN(t *testing.T) {
 	for _, x := range []struct {
 		input    string
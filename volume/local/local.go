@@ -17,6 +17,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api"
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/volume"
@@ -70,6 +71,14 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 		rootGID: rootGID,
 	}
 
+	// Size limited volumes need project quota support on the filesystem
+	// backing the volumes directory.
+	if quotaCtl, err := quota.NewControl(rootDirectory); err == nil {
+		r.quotaCtl = quotaCtl
+	} else {
+		logrus.Debugf("project quotas not supported for local volumes: %v", err)
+	}
+
 	dirs, err := ioutil.ReadDir(rootDirectory)
 	if err != nil {
 		return nil, err
@@ -90,6 +99,7 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 			driverName: r.Name(),
 			name:       name,
 			path:       r.DataPath(name),
+			quotaCtl:   r.quotaCtl,
 		}
 		r.volumes[name] = v
 		optsFilePath := filepath.Join(rootDirectory, name, "opts.json")
@@ -127,6 +137,9 @@ type Root struct {
 	volumes map[string]*localVolume
 	rootUID int
 	rootGID int
+	// quotaCtl sets the size limit of volumes, nil if project quotas are
+	// not supported
+	quotaCtl *quota.Control
 }
 
 // List lists all the volumes
@@ -185,12 +198,16 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		driverName: r.Name(),
 		name:       name,
 		path:       path,
+		quotaCtl:   r.quotaCtl,
 	}
 
 	if len(opts) != 0 {
 		if err = setOpts(v, opts); err != nil {
 			return nil, err
 		}
+		if err = v.setQuota(); err != nil {
+			return nil, err
+		}
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
@@ -234,6 +251,12 @@ func (r *Root) Remove(v volume.Volume) error {
 		return err
 	}
 
+	if r.quotaCtl != nil {
+		if err := r.quotaCtl.ClearQuota(filepath.Dir(lv.path)); err != nil {
+			logrus.Warnf("error clearing quota of volume %s: %v", lv.name, err)
+		}
+	}
+
 	delete(r.volumes, lv.name)
 	return removePath(filepath.Dir(lv.path))
 }
@@ -288,6 +311,8 @@ type localVolume struct {
 	opts *optsConfig
 	// active refcounts the active mounts
 	active activeMount
+	// quotaCtl is used to read the size limit and usage of the volume
+	quotaCtl *quota.Control
 }
 
 // Name returns the name of the given Volume.
@@ -309,7 +334,7 @@ func (v *localVolume) Path() string {
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
 				return "", err
@@ -325,7 +350,7 @@ func (v *localVolume) Mount(id string) (string, error) {
 func (v *localVolume) Unmount(id string) error {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		v.active.count--
 		if v.active.count == 0 {
 			if err := mount.Unmount(v.path); err != nil {
@@ -347,8 +372,21 @@ func validateOpts(opts map[string]string) error {
 	return nil
 }
 
+// Status returns the size limit and current usage of the volume, if it has
+// been created with a size.
 func (v *localVolume) Status() map[string]interface{} {
-	return nil
+	limit, usage, err := v.SizeLimit()
+	if err != nil {
+		logrus.Debugf("error getting quota of volume %s: %v", v.name, err)
+		return nil
+	}
+	if limit == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"Size":  limit,
+		"Usage": usage,
+	}
 }
 
 // getAddress finds out address/hostname from options
@@ -342,3 +342,55 @@ func TestRealodNoOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateWithSizeOpt(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "solaris" {
+		t.Skip()
+	}
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []map[string]string{
+		{"size": "notasize"},
+		{"size": "0"},
+		{"size": "1g", "type": "tmpfs", "device": "tmpfs"},
+	} {
+		if _, err := r.Create("test", opts); err == nil {
+			t.Fatalf("expected error creating volume with opts %v", opts)
+		}
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "1g"})
+	if r.quotaCtl == nil {
+		// the test directory is not on a filesystem supporting project quotas
+		if err == nil {
+			t.Fatal("expected error creating a size limited volume without quota support")
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.needsMount() {
+		t.Fatal("expected size limited volume not to be mounted")
+	}
+	limit, _, err := v.SizeLimit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit != 1024*1024*1024 {
+		t.Fatalf("expected size limit of 1g, got %d", limit)
+	}
+	if status := v.Status(); status["Size"] != limit {
+		t.Fatalf("expected size limit in volume status, got %v", status)
+	}
+}
@@ -13,7 +13,9 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/mount"
+	"github.com/docker/go-units"
 )
 
 var (
@@ -23,6 +25,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // size limit of the volume, enforced by project quotas
 	}
 )
 
@@ -30,10 +33,11 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	Quota       quota.Quota
 }
 
 func (o *optsConfig) String() string {
-	return fmt.Sprintf("type='%s' device='%s' o='%s'", o.MountType, o.MountDevice, o.MountOpts)
+	return fmt.Sprintf("type='%s' device='%s' o='%s' size='%d'", o.MountType, o.MountDevice, o.MountOpts, o.Quota.Size)
 }
 
 // scopedPath verifies that the path where the volume is located
@@ -65,9 +69,60 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	if val, ok := opts["size"]; ok {
+		if v.needsMount() {
+			return validationError{fmt.Errorf("size option cannot be combined with type, device or o options")}
+		}
+		size, err := units.RAMInBytes(val)
+		if err != nil {
+			return validationError{errors.Wrapf(err, "invalid size: %q", val)}
+		}
+		if size <= 0 {
+			return validationError{fmt.Errorf("invalid size: %q, size must be positive", val)}
+		}
+		v.opts.Quota.Size = uint64(size)
+	}
 	return nil
 }
 
+// needsMount returns whether the volume is mounted from a device, rather
+// than being a plain directory.
+func (v *localVolume) needsMount() bool {
+	if v.opts == nil {
+		return false
+	}
+	return v.opts.MountType != "" || v.opts.MountOpts != "" || v.opts.MountDevice != ""
+}
+
+// setQuota applies the size limit of the volume to its directory.
+func (v *localVolume) setQuota() error {
+	if v.opts == nil || v.opts.Quota.Size == 0 {
+		return nil
+	}
+	if v.quotaCtl == nil {
+		return validationError{fmt.Errorf("size option is not supported: the filesystem of the volumes directory does not support project quotas")}
+	}
+	return v.quotaCtl.SetQuota(filepath.Dir(v.path), v.opts.Quota)
+}
+
+// SizeLimit returns the size limit of the volume and the disk space it uses,
+// in bytes. The limit is zero if the volume was not created with a size.
+func (v *localVolume) SizeLimit() (limit, usage uint64, err error) {
+	if v.opts == nil || v.opts.Quota.Size == 0 || v.quotaCtl == nil {
+		return 0, 0, nil
+	}
+	path := filepath.Dir(v.path)
+	var q quota.Quota
+	if err := v.quotaCtl.GetQuota(path, &q); err != nil {
+		return 0, 0, err
+	}
+	usage, err = v.quotaCtl.GetUsage(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	return q.Size, usage, nil
+}
+
 func (v *localVolume) mount() error {
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
@@ -32,3 +32,17 @@ func setOpts(v *localVolume, opts map[string]string) error {
 func (v *localVolume) mount() error {
 	return nil
 }
+
+func (v *localVolume) needsMount() bool {
+	return false
+}
+
+func (v *localVolume) setQuota() error {
+	return nil
+}
+
+// SizeLimit returns the size limit of the volume and the disk space it uses.
+// Size limits are not supported on Windows, so the limit is always zero.
+func (v *localVolume) SizeLimit() (limit, usage uint64, err error) {
+	return 0, 0, nil
+}
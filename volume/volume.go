@@ -76,6 +76,14 @@ type DetailedVolume interface {
 	Volume
 }
 
+// SizedVolume is a volume whose size is limited by its driver
+type SizedVolume interface {
+	Volume
+	// SizeLimit returns the size limit of the volume and the disk space it
+	// uses, in bytes. The limit is zero if the volume is not limited.
+	SizeLimit() (limit, usage uint64, err error)
+}
+
 // MountPoint is the intersection point between a volume and a container. It
 // specifies which volume is to be used and where inside a container it should
 // be mounted.